@@ -194,6 +194,214 @@ func (client *BaseClient) detectAPIVersion(ctx context.Context) (int, error) {
 	return 0, nil
 }
 
+// ServerInfo represents a PowerDNS server object, as returned by the
+// authoritative server and the recursor alike.
+type ServerInfo struct {
+	ID         string `json:"id"`
+	DaemonType string `json:"daemon_type"`
+	Version    string `json:"version"`
+	URL        string `json:"url"`
+	ConfigURL  string `json:"config_url"`
+	ZonesURL   string `json:"zones_url"`
+}
+
+// ServerConfigSetting is a setting in the running configuration of a server.
+// The recursor reports some settings as a list; Value joins them with ", ".
+type ServerConfigSetting struct {
+	Name  string
+	Value string
+}
+
+// UnmarshalJSON accepts a value that is a string or a list of strings.
+func (setting *ServerConfigSetting) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Name  string          `json:"name"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	setting.Name = raw.Name
+
+	var values []string
+	if err := json.Unmarshal(raw.Value, &values); err == nil {
+		setting.Value = strings.Join(values, ", ")
+		return nil
+	}
+	return json.Unmarshal(raw.Value, &setting.Value)
+}
+
+// GetServer returns the server object of serverID.
+func (client *BaseClient) GetServer(ctx context.Context, serverID string) (ServerInfo, error) {
+	req, err := client.newRequest(ctx, http.MethodGet, "/servers/"+url.PathEscape(serverID), nil)
+	if err != nil {
+		return ServerInfo{}, err
+	}
+
+	resp, err := client.HTTP.Do(req)
+	if err != nil {
+		return ServerInfo{}, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			tflog.Warn(ctx, "Error closing response body", map[string]interface{}{
+				"error":  err.Error(),
+				"method": req.Method,
+				"url":    req.URL.String(),
+			})
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		errorResp := new(errorResponse)
+		if err = json.NewDecoder(resp.Body).Decode(errorResp); err != nil {
+			return ServerInfo{}, fmt.Errorf("error getting server: %s", serverID)
+		}
+		return ServerInfo{}, fmt.Errorf("error getting server: %s, reason: %q", serverID, errorResp.ErrorMsg)
+	}
+
+	var server ServerInfo
+	if err := json.NewDecoder(resp.Body).Decode(&server); err != nil {
+		return ServerInfo{}, err
+	}
+	return server, nil
+}
+
+// GetServerConfig returns the running configuration of serverID.
+func (client *BaseClient) GetServerConfig(ctx context.Context, serverID string) ([]ServerConfigSetting, error) {
+	req, err := client.newRequest(ctx, http.MethodGet, "/servers/"+url.PathEscape(serverID)+"/config", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.HTTP.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			tflog.Warn(ctx, "Error closing response body", map[string]interface{}{
+				"error":  err.Error(),
+				"method": req.Method,
+				"url":    req.URL.String(),
+			})
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		errorResp := new(errorResponse)
+		if err = json.NewDecoder(resp.Body).Decode(errorResp); err != nil {
+			return nil, fmt.Errorf("error getting config of server: %s", serverID)
+		}
+		return nil, fmt.Errorf("error getting config of server: %s, reason: %q", serverID, errorResp.ErrorMsg)
+	}
+
+	var settings []ServerConfigSetting
+	if err := json.NewDecoder(resp.Body).Decode(&settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// Statistic types returned by the statistics endpoint.
+const (
+	StatisticItemType     = "StatisticItem"
+	MapStatisticItemType  = "MapStatisticItem"
+	RingStatisticItemType = "RingStatisticItem"
+)
+
+// SimpleStatisticItem is an entry of a map or ring statistic.
+type SimpleStatisticItem struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// StatisticItem is a statistic of a server. A StatisticItem has a Value,
+// a MapStatisticItem has Entries, and a RingStatisticItem has Entries and
+// the Size of the ring.
+type StatisticItem struct {
+	Type    string
+	Name    string
+	Value   string
+	Size    int
+	Entries []SimpleStatisticItem
+}
+
+// UnmarshalJSON decodes the value of a statistic into Value or Entries,
+// depending on its type.
+func (item *StatisticItem) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Type  string          `json:"type"`
+		Name  string          `json:"name"`
+		Size  json.Number     `json:"size"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*item = StatisticItem{Type: raw.Type, Name: raw.Name}
+
+	// The recursor reports the size of a ring as a string.
+	if raw.Size != "" {
+		size, err := strconv.Atoi(raw.Size.String())
+		if err != nil {
+			return fmt.Errorf("invalid size of statistic %s: %w", raw.Name, err)
+		}
+		item.Size = size
+	}
+
+	if len(raw.Value) == 0 {
+		return nil
+	}
+	if item.Type == StatisticItemType {
+		return json.Unmarshal(raw.Value, &item.Value)
+	}
+	return json.Unmarshal(raw.Value, &item.Entries)
+}
+
+// GetStatistics returns the statistics of serverID. An empty statistic returns
+// all of them; includeRings set to false leaves out the ring statistics.
+func (client *BaseClient) GetStatistics(ctx context.Context, serverID, statistic string, includeRings bool) ([]StatisticItem, error) {
+	params := url.Values{}
+	if statistic != "" {
+		params.Set("statistic", statistic)
+	}
+	params.Set("includerings", strconv.FormatBool(includeRings))
+
+	req, err := client.newRequest(ctx, http.MethodGet, "/servers/"+url.PathEscape(serverID)+"/statistics?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.HTTP.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			tflog.Warn(ctx, "Error closing response body", map[string]interface{}{
+				"error":  err.Error(),
+				"method": req.Method,
+				"url":    req.URL.String(),
+			})
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		errorResp := new(errorResponse)
+		if err = json.NewDecoder(resp.Body).Decode(errorResp); err != nil {
+			return nil, fmt.Errorf("error getting statistics of server: %s", serverID)
+		}
+		return nil, fmt.Errorf("error getting statistics of server: %s, reason: %q", serverID, errorResp.ErrorMsg)
+	}
+
+	var statistics []StatisticItem
+	if err := json.NewDecoder(resp.Body).Decode(&statistics); err != nil {
+		return nil, err
+	}
+	return statistics, nil
+}
+
 // PowerDNSClient is the concrete client used by the provider.
 // ZoneInfo represents a PowerDNS zone object
 type ZoneInfo struct {
@@ -210,16 +418,36 @@ type ZoneInfo struct {
 	Nameservers        []string            `json:"nameservers,omitempty"`
 	Masters            []string            `json:"masters,omitempty"`
 	SoaEditAPI         string              `json:"soa_edit_api"`
+	// Zone is a BIND zone file to create the zone from; it is only sent on
+	// creation and never returned.
+	Zone string `json:"zone,omitempty"`
 }
 
 // ZoneInfoUpd is a limited subset for supported updates
 type ZoneInfoUpd struct {
-	Name       string   `json:"name"`
-	Kind       string   `json:"kind"`
-	Catalog    string   `json:"catalog,omitempty"`
-	SoaEditAPI string   `json:"soa_edit_api,omitempty"`
-	Account    string   `json:"account"`
-	Masters    []string `json:"masters,omitempty"`
+	Name    string `json:"name"`
+	Kind    string `json:"kind"`
+	Catalog string `json:"catalog,omitempty"`
+	// SoaEditAPI is left unchanged when nil; an empty value removes the
+	// setting.
+	SoaEditAPI *string `json:"soa_edit_api,omitempty"`
+	Account    string  `json:"account"`
+	// Masters is sent as null when nil, which leaves the primaries of the
+	// zone unchanged. An empty list removes them.
+	Masters []string `json:"masters"`
+}
+
+// SearchResult is a zone, record or comment found by SearchData. Zone
+// results only have a name and a zone id.
+type SearchResult struct {
+	ObjectType string `json:"object_type"`
+	Name       string `json:"name"`
+	Zone       string `json:"zone"`
+	ZoneID     string `json:"zone_id"`
+	Type       string `json:"type"`
+	Content    string `json:"content"`
+	TTL        int    `json:"ttl"`
+	Disabled   bool   `json:"disabled"`
 }
 
 // View represents a PowerDNS view object.
@@ -345,6 +573,42 @@ func (client *PowerDNSClient) serverEndpoint(path string) string {
 	return "/servers/" + url.PathEscape(client.serverID) + path
 }
 
+// zoneEndpoint returns the API path of a zone, followed by path.
+func (client *PowerDNSClient) zoneEndpoint(zone string, path string) string {
+	return client.serverEndpoint("/zones/" + zoneID(zone) + path)
+}
+
+// zoneID turns a zone name into the zone id PowerDNS expects in URLs. Most
+// names are their own id, but RFC 2317 zones such as 0/26.2.0.192.in-addr.arpa.
+// contain a slash, which PowerDNS encodes as =2F; "=" is the escape character
+// itself and is encoded as =3D. An id, such as the one PowerDNS returns when
+// a zone is created, is passed through unchanged rather than escaped again.
+func zoneID(zone string) string {
+	if isZoneID(zone) {
+		return zone
+	}
+	return strings.NewReplacer("=", "=3D", "/", "=2F").Replace(zone)
+}
+
+// isZoneID reports whether zone is already in the id form: it has no slash,
+// and every "=" in it starts a two digit hex escape.
+func isZoneID(zone string) bool {
+	if strings.Contains(zone, "/") {
+		return false
+	}
+	for i := strings.IndexByte(zone, '='); i >= 0; i = strings.IndexByte(zone, '=') {
+		if len(zone) < i+3 || !isHexDigit(zone[i+1]) || !isHexDigit(zone[i+2]) {
+			return false
+		}
+		zone = zone[i+3:]
+	}
+	return true
+}
+
+func isHexDigit(c byte) bool {
+	return ('0' <= c && c <= '9') || ('A' <= c && c <= 'F') || ('a' <= c && c <= 'f')
+}
+
 // ListZones returns all Zones of server, without records
 func (client *PowerDNSClient) ListZones(ctx context.Context) ([]ZoneInfo, error) {
 	req, err := client.newRequest(ctx, http.MethodGet, client.serverEndpoint("/zones"), nil)
@@ -393,7 +657,7 @@ func (client *PowerDNSClient) GetZoneWithRRsets(ctx context.Context, name string
 }
 
 func (client *PowerDNSClient) getZone(ctx context.Context, name string, includeRRsets bool) (ZoneInfo, error) {
-	endpoint := client.serverEndpoint(fmt.Sprintf("/zones/%s", name))
+	endpoint := client.zoneEndpoint(name, "")
 	if includeRRsets {
 		endpoint += "?rrsets=true"
 	}
@@ -418,7 +682,10 @@ func (client *PowerDNSClient) getZone(ctx context.Context, name string, includeR
 		}
 	}()
 
-	if resp.StatusCode != http.StatusOK {
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ZoneInfo{}, fmt.Errorf("error getting zone: %s: %w", name, ErrNotFound)
+	case resp.StatusCode != http.StatusOK:
 		errorResp := new(errorResponse)
 		if err = json.NewDecoder(resp.Body).Decode(errorResp); err != nil {
 			return ZoneInfo{}, fmt.Errorf("error getting zone: %s", name)
@@ -436,7 +703,7 @@ func (client *PowerDNSClient) getZone(ctx context.Context, name string, includeR
 
 // ZoneExists checks if requested zone exists
 func (client *PowerDNSClient) ZoneExists(ctx context.Context, name string) (bool, error) {
-	req, err := client.newRequest(ctx, http.MethodGet, client.serverEndpoint(fmt.Sprintf("/zones/%s", name)), nil)
+	req, err := client.newRequest(ctx, http.MethodGet, client.zoneEndpoint(name, ""), nil)
 	if err != nil {
 		return false, err
 	}
@@ -517,7 +784,7 @@ func (client *PowerDNSClient) UpdateZone(ctx context.Context, name string, zoneI
 		return err
 	}
 
-	req, err := client.newRequest(ctx, http.MethodPut, client.serverEndpoint(fmt.Sprintf("/zones/%s", name)), body)
+	req, err := client.newRequest(ctx, http.MethodPut, client.zoneEndpoint(name, ""), body)
 	if err != nil {
 		return err
 	}
@@ -550,7 +817,7 @@ func (client *PowerDNSClient) UpdateZone(ctx context.Context, name string, zoneI
 
 // DeleteZone deletes a zone
 func (client *PowerDNSClient) DeleteZone(ctx context.Context, name string) error {
-	req, err := client.newRequest(ctx, http.MethodDelete, client.serverEndpoint(fmt.Sprintf("/zones/%s", name)), nil)
+	req, err := client.newRequest(ctx, http.MethodDelete, client.zoneEndpoint(name, ""), nil)
 	if err != nil {
 		return err
 	}
@@ -580,9 +847,93 @@ func (client *PowerDNSClient) DeleteZone(ctx context.Context, name string) error
 	return nil
 }
 
+// RetrieveZone asks the server to transfer a secondary zone from its
+// primaries. The transfer runs in the background.
+func (client *PowerDNSClient) RetrieveZone(ctx context.Context, name string) error {
+	req, err := client.newRequest(ctx, http.MethodPut, client.zoneEndpoint(name, "/axfr-retrieve"), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.HTTP.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			tflog.Warn(ctx, "Error closing response body", map[string]interface{}{
+				"error":  err.Error(),
+				"method": req.Method,
+				"url":    req.URL.String(),
+				"zone":   name,
+			})
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		errorResp := new(errorResponse)
+		if err = json.NewDecoder(resp.Body).Decode(errorResp); err != nil {
+			return fmt.Errorf("error retrieving zone: %s", name)
+		}
+		return fmt.Errorf("error retrieving zone: %s, reason: %q", name, errorResp.ErrorMsg)
+	}
+	return nil
+}
+
+// ExportZone returns the zone in AXFR format, as BIND zone file text.
+func (client *PowerDNSClient) ExportZone(ctx context.Context, name string) (string, error) {
+	req, err := client.newRequest(ctx, http.MethodGet, client.zoneEndpoint(name, "/export"), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "text/plain")
+
+	resp, err := client.HTTP.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			tflog.Warn(ctx, "Error closing response body", map[string]interface{}{
+				"error":  err.Error(),
+				"method": req.Method,
+				"url":    req.URL.String(),
+				"zone":   name,
+			})
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		errorResp := new(errorResponse)
+		if err = json.NewDecoder(resp.Body).Decode(errorResp); err != nil {
+			return "", fmt.Errorf("error exporting zone: %s, status: %d", name, resp.StatusCode)
+		}
+		return "", fmt.Errorf("error exporting zone: %s, reason: %q", name, errorResp.ErrorMsg)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	// Some versions answer with {"zone": "..."} regardless of the Accept
+	// header.
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		var export struct {
+			Zone string `json:"zone"`
+		}
+		if err := json.Unmarshal(body, &export); err != nil {
+			return "", err
+		}
+		return export.Zone, nil
+	}
+
+	return string(body), nil
+}
+
 // ListZoneMetadata returns all domain metadata entries for a zone.
 func (client *PowerDNSClient) ListZoneMetadata(ctx context.Context, zone string) ([]ZoneMetadata, error) {
-	req, err := client.newRequest(ctx, http.MethodGet, client.serverEndpoint(fmt.Sprintf("/zones/%s/metadata", zone)), nil)
+	req, err := client.newRequest(ctx, http.MethodGet, client.zoneEndpoint(zone, "/metadata"), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -620,7 +971,7 @@ func (client *PowerDNSClient) ListZoneMetadata(ctx context.Context, zone string)
 
 // GetZoneMetadata returns one metadata kind for a zone.
 func (client *PowerDNSClient) GetZoneMetadata(ctx context.Context, zone string, kind string) (ZoneMetadata, error) {
-	req, err := client.newRequest(ctx, http.MethodGet, client.serverEndpoint(fmt.Sprintf("/zones/%s/metadata/%s", zone, kind)), nil)
+	req, err := client.newRequest(ctx, http.MethodGet, client.zoneEndpoint(zone, "/metadata/"+kind), nil)
 	if err != nil {
 		return ZoneMetadata{}, err
 	}
@@ -666,7 +1017,7 @@ func (client *PowerDNSClient) ReplaceZoneMetadata(ctx context.Context, zone stri
 	if err != nil {
 		return err
 	}
-	req, err := client.newRequest(ctx, http.MethodPut, client.serverEndpoint(fmt.Sprintf("/zones/%s/metadata/%s", zone, kind)), body)
+	req, err := client.newRequest(ctx, http.MethodPut, client.zoneEndpoint(zone, "/metadata/"+kind), body)
 	if err != nil {
 		return err
 	}
@@ -700,7 +1051,7 @@ func (client *PowerDNSClient) ReplaceZoneMetadata(ctx context.Context, zone stri
 
 // DeleteZoneMetadata deletes all values for a metadata kind in a zone.
 func (client *PowerDNSClient) DeleteZoneMetadata(ctx context.Context, zone string, kind string) error {
-	req, err := client.newRequest(ctx, http.MethodDelete, client.serverEndpoint(fmt.Sprintf("/zones/%s/metadata/%s", zone, kind)), nil)
+	req, err := client.newRequest(ctx, http.MethodDelete, client.zoneEndpoint(zone, "/metadata/"+kind), nil)
 	if err != nil {
 		return err
 	}
@@ -763,7 +1114,7 @@ func (client *PowerDNSClient) ListRecords(ctx context.Context, zone string) ([]R
 	}
 
 	if zoneInfo == nil {
-		req, err := client.newRequest(ctx, http.MethodGet, client.serverEndpoint(fmt.Sprintf("/zones/%s?rrsets=true", zone)), nil)
+		req, err := client.newRequest(ctx, http.MethodGet, client.zoneEndpoint(zone, "?rrsets=true"), nil)
 		if err != nil {
 			return nil, err
 		}
@@ -906,7 +1257,7 @@ func (client *PowerDNSClient) ReplaceRecordSet(ctx context.Context, zone string,
 		RecordSets: []ResourceRecordSet{rrSet},
 	})
 
-	req, err := client.newRequest(ctx, http.MethodPatch, client.serverEndpoint(fmt.Sprintf("/zones/%s", zone)), reqBody)
+	req, err := client.newRequest(ctx, http.MethodPatch, client.zoneEndpoint(zone, ""), reqBody)
 	if err != nil {
 		return "", err
 	}
@@ -949,7 +1300,7 @@ func (client *PowerDNSClient) DeleteRecordSet(ctx context.Context, zone string,
 		},
 	})
 
-	req, err := client.newRequest(ctx, http.MethodPatch, client.serverEndpoint(fmt.Sprintf("/zones/%s", zone)), reqBody)
+	req, err := client.newRequest(ctx, http.MethodPatch, client.zoneEndpoint(zone, ""), reqBody)
 	if err != nil {
 		return err
 	}
@@ -990,6 +1341,87 @@ func (client *PowerDNSClient) DeleteRecordSetByID(ctx context.Context, zone stri
 	return client.DeleteRecordSet(ctx, zone, name, tpe)
 }
 
+// PatchRecordSets applies several RRset changes to Zone in one request. Each
+// RRset carries its own ChangeType, REPLACE or DELETE, and PowerDNS applies
+// them all or none.
+func (client *PowerDNSClient) PatchRecordSets(ctx context.Context, zone string, rrSets []ResourceRecordSet) error {
+	reqBody, _ := json.Marshal(zonePatchRequest{
+		RecordSets: rrSets,
+	})
+
+	req, err := client.newRequest(ctx, http.MethodPatch, client.zoneEndpoint(zone, ""), reqBody)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.HTTP.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			tflog.Warn(ctx, "Error closing response body", map[string]interface{}{
+				"error":  err.Error(),
+				"method": req.Method,
+				"url":    req.URL.String(),
+				"zone":   zone,
+			})
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		errorResp := new(errorResponse)
+		if err = json.NewDecoder(resp.Body).Decode(errorResp); err != nil {
+			return fmt.Errorf("error patching record sets in zone: %s", zone)
+		}
+		return fmt.Errorf("error patching record sets in zone: %s, reason: %q", zone, errorResp.ErrorMsg)
+	}
+	return nil
+}
+
+// SearchData searches the zones, records and comments of the server. query
+// may contain the wildcards * and ?. At most maxResults results of
+// objectType, one of all, zone, record or comment, are returned.
+func (client *PowerDNSClient) SearchData(ctx context.Context, query string, maxResults int, objectType string) ([]SearchResult, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("max", strconv.Itoa(maxResults))
+	params.Set("object_type", objectType)
+
+	req, err := client.newRequest(ctx, http.MethodGet, client.serverEndpoint("/search-data?"+params.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.HTTP.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			tflog.Warn(ctx, "Error closing response body", map[string]interface{}{
+				"error":  err.Error(),
+				"method": req.Method,
+				"url":    req.URL.String(),
+			})
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		errorResp := new(errorResponse)
+		if err = json.NewDecoder(resp.Body).Decode(errorResp); err != nil {
+			return nil, fmt.Errorf("error searching for %q", query)
+		}
+		return nil, fmt.Errorf("error searching for %q, reason: %q", query, errorResp.ErrorMsg)
+	}
+
+	var results []SearchResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
 // ListViews returns all configured views.
 func (client *PowerDNSClient) ListViews(ctx context.Context) ([]string, error) {
 	req, err := client.newRequest(ctx, http.MethodGet, client.serverEndpoint("/views"), nil)
@@ -1113,7 +1545,7 @@ func (client *PowerDNSClient) AddZoneToView(ctx context.Context, viewName, zoneN
 
 // RemoveZoneFromView removes a zone from a view.
 func (client *PowerDNSClient) RemoveZoneFromView(ctx context.Context, viewName, zoneName string) error {
-	req, err := client.newRequest(ctx, http.MethodDelete, client.serverEndpoint(fmt.Sprintf("/views/%s/%s", viewName, zoneName)), nil)
+	req, err := client.newRequest(ctx, http.MethodDelete, client.serverEndpoint(fmt.Sprintf("/views/%s/%s", viewName, zoneID(zoneName))), nil)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,173 @@
+package powerdns
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZoneID(t *testing.T) {
+	assert.Equal(t, "example.com.", zoneID("example.com."))
+	assert.Equal(t, "example.com..internal", zoneID("example.com..internal"))
+	assert.Equal(t, "0=2F26.2.0.192.in-addr.arpa.", zoneID("0/26.2.0.192.in-addr.arpa."))
+	assert.Equal(t, "a=3Db.example.com.", zoneID("a=b.example.com."))
+
+	// Ids returned by PowerDNS are not escaped again.
+	assert.Equal(t, "0=2F26.2.0.192.in-addr.arpa.", zoneID("0=2F26.2.0.192.in-addr.arpa."))
+	assert.Equal(t, "a=3Db.example.com.", zoneID("a=3Db.example.com."))
+}
+
+func TestZoneEndpointWithServerID(t *testing.T) {
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/api/v1/servers/localhost/zones/0=2F26.2.0.192.in-addr.arpa.", r.URL.Path)
+		return jsonResponse(http.StatusOK, `{"id":"0=2F26.2.0.192.in-addr.arpa.","name":"0/26.2.0.192.in-addr.arpa.","kind":"Native"}`), nil
+	})
+
+	// powerdns_zone and powerdns_catalog_zone use the id PowerDNS returned
+	// on creation as their resource id.
+	assert.Equal(t, "/servers/localhost/zones/0=2F26.2.0.192.in-addr.arpa.", client.zoneEndpoint("0=2F26.2.0.192.in-addr.arpa.", ""))
+	zone, err := client.GetZone(context.Background(), "0=2F26.2.0.192.in-addr.arpa.")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "0/26.2.0.192.in-addr.arpa.", zone.Name)
+	}
+}
+
+func TestClasslessZoneRoutes(t *testing.T) {
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/api/v1/servers/localhost/zones/0=2F26.2.0.192.in-addr.arpa.", r.URL.Path)
+		return jsonResponse(http.StatusOK, `{"name":"0/26.2.0.192.in-addr.arpa.","kind":"Native"}`), nil
+	})
+
+	zone, err := client.GetZone(context.Background(), "0/26.2.0.192.in-addr.arpa.")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "0/26.2.0.192.in-addr.arpa.", zone.Name)
+	}
+}
+
+func TestPatchRecordSets(t *testing.T) {
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/api/v1/servers/localhost/zones/2.0.192.in-addr.arpa.", r.URL.Path)
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		var patch zonePatchRequest
+		assert.NoError(t, json.Unmarshal(body, &patch))
+		if assert.Len(t, patch.RecordSets, 2) {
+			assert.Equal(t, "REPLACE", patch.RecordSets[0].ChangeType)
+			assert.Equal(t, "DELETE", patch.RecordSets[1].ChangeType)
+		}
+		return jsonResponse(http.StatusNoContent, ``), nil
+	})
+
+	err := client.PatchRecordSets(context.Background(), "2.0.192.in-addr.arpa.", []ResourceRecordSet{
+		{Name: "1.2.0.192.in-addr.arpa.", Type: "CNAME", ChangeType: "REPLACE", TTL: 3600, Records: []Record{{Content: "1.0/26.2.0.192.in-addr.arpa."}}},
+		{Name: "0/26.2.0.192.in-addr.arpa.", Type: "NS", ChangeType: "DELETE"},
+	})
+	assert.NoError(t, err)
+}
+
+func TestPatchRecordSetsError(t *testing.T) {
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusUnprocessableEntity, `{"error":"RRset conflicts"}`), nil
+	})
+
+	err := client.PatchRecordSets(context.Background(), "2.0.192.in-addr.arpa.", []ResourceRecordSet{
+		{Name: "1.2.0.192.in-addr.arpa.", Type: "CNAME", ChangeType: "DELETE"},
+	})
+	assert.ErrorContains(t, err, `reason: "RRset conflicts"`)
+}
+
+func TestExportZone(t *testing.T) {
+	const zoneText = "example.com.\t3600\tIN\tSOA\tns1.example.com. hostmaster.example.com. 1 10800 3600 604800 3600\n"
+
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/api/v1/servers/localhost/zones/example.com./export", r.URL.Path)
+		assert.Equal(t, "text/plain", r.Header.Get("Accept"))
+		return jsonResponse(http.StatusOK, zoneText), nil
+	})
+	content, err := client.ExportZone(context.Background(), "example.com.")
+	assert.NoError(t, err)
+	assert.Equal(t, zoneText, content)
+
+	// Some versions wrap the text in JSON.
+	client = newTestClient(func(r *http.Request) (*http.Response, error) {
+		body, _ := json.Marshal(map[string]string{"zone": zoneText})
+		resp := jsonResponse(http.StatusOK, string(body))
+		resp.Header.Set("Content-Type", "application/json")
+		return resp, nil
+	})
+	content, err = client.ExportZone(context.Background(), "example.com.")
+	assert.NoError(t, err)
+	assert.Equal(t, zoneText, content)
+
+	client = newTestClient(func(r *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusNotFound, `{"error": "Could not find domain 'missing.example.com.'"}`), nil
+	})
+	_, err = client.ExportZone(context.Background(), "missing.example.com.")
+	assert.ErrorContains(t, err, "Could not find domain")
+}
+
+func TestUpdateZoneMastersAndSOAEditAPI(t *testing.T) {
+	var bodies []map[string]interface{}
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		bodies = append(bodies, body)
+		return jsonResponse(http.StatusNoContent, ""), nil
+	})
+
+	empty := ""
+	assert.NoError(t, client.UpdateZone(context.Background(), "example.com.", ZoneInfoUpd{Name: "example.com.", Kind: "Master"}))
+	assert.NoError(t, client.UpdateZone(context.Background(), "example.com.", ZoneInfoUpd{Name: "example.com.", Kind: "Master", Masters: []string{}, SoaEditAPI: &empty}))
+
+	// Unset fields leave the zone unchanged; empty ones clear it.
+	assert.Nil(t, bodies[0]["masters"])
+	assert.NotContains(t, bodies[0], "soa_edit_api")
+	assert.Equal(t, []interface{}{}, bodies[1]["masters"])
+	assert.Equal(t, "", bodies[1]["soa_edit_api"])
+}
+
+func TestRetrieveZone(t *testing.T) {
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/api/v1/servers/localhost/zones/example.com./axfr-retrieve", r.URL.Path)
+		return jsonResponse(http.StatusOK, `{"result": "Added retrieval request for 'example.com.' from primary 192.0.2.1"}`), nil
+	})
+	assert.NoError(t, client.RetrieveZone(context.Background(), "example.com."))
+
+	client = newTestClient(func(r *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusUnprocessableEntity, `{"error": "Domain 'example.com.' is not a secondary domain (or has no primary defined)"}`), nil
+	})
+	assert.ErrorContains(t, client.RetrieveZone(context.Background(), "example.com."), "is not a secondary domain")
+}
+
+func TestSearchData(t *testing.T) {
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/api/v1/servers/localhost/search-data", r.URL.Path)
+		assert.Equal(t, "10.20.30.*", r.URL.Query().Get("q"))
+		assert.Equal(t, "50", r.URL.Query().Get("max"))
+		assert.Equal(t, "record", r.URL.Query().Get("object_type"))
+		return jsonResponse(http.StatusOK, `[
+			{"object_type": "record", "name": "db.example.com.", "zone": "example.com.", "zone_id": "example.com.", "type": "A", "content": "10.20.30.40", "ttl": 300, "disabled": true}
+		]`), nil
+	})
+
+	results, err := client.SearchData(context.Background(), "10.20.30.*", 50, "record")
+	assert.NoError(t, err)
+	assert.Equal(t, []SearchResult{{
+		ObjectType: "record",
+		Name:       "db.example.com.",
+		Zone:       "example.com.",
+		ZoneID:     "example.com.",
+		Type:       "A",
+		Content:    "10.20.30.40",
+		TTL:        300,
+		Disabled:   true,
+	}}, results)
+}
@@ -0,0 +1,280 @@
+package powerdns
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// dataSourcePDNSAvailableIPs returns free addresses in a block, treating every
+// address with a PTR record in a covering reverse zone as taken.
+func dataSourcePDNSAvailableIPs() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePDNSAvailableIPsRead,
+
+		Schema: map[string]*schema.Schema{
+			"cidr": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsCIDR,
+				Description:  "The IPv4 or IPv6 block to allocate addresses from (e.g., '192.0.2.0/24').",
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntBetween(1, 1024),
+				Description:  "The number of free addresses to return.",
+			},
+			"exclude": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.Any(validation.IsIPAddress, validation.IsCIDR),
+				},
+				Description: "Addresses or CIDR blocks that must not be returned, such as gateways or DHCP ranges.",
+			},
+			"forward_zones": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: ValidateZoneName,
+				},
+				Description: "Forward zones whose A and AAAA records also mark addresses as taken.",
+			},
+			"include_network_and_broadcast": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the network and broadcast addresses of an IPv4 block of /30 or larger may be returned.",
+			},
+			"reverse_zones": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The reverse zones that were scanned for PTR records.",
+			},
+			"ip_addresses": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The free addresses, in ascending order.",
+			},
+		},
+	}
+}
+
+func dataSourcePDNSAvailableIPsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderClients)
+
+	_, block, err := net.ParseCIDR(d.Get("cidr").(string))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("invalid cidr: %w", err))
+	}
+	ctx = tflog.SetField(ctx, "cidr", block.String())
+	tflog.Info(ctx, "Reading available IPs data source")
+
+	excluded, err := parseIPExclusions(expandStringSet(d.Get("exclude").(*schema.Set)))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	zones, err := client.PDNS.ListZones(ctx)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't list zones: %w", err))
+	}
+	reverseZones := findReverseZonesForBlock(zones, block)
+	if len(reverseZones) == 0 {
+		return diag.FromErr(fmt.Errorf("no reverse zone on the server covers %s", block))
+	}
+
+	used := map[string]bool{}
+	for _, zone := range reverseZones {
+		records, err := client.PDNS.ListRecords(ctx, zone)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("couldn't fetch records of zone %s: %w", zone, err))
+		}
+		// A CNAME in a reverse zone delegates the address to an RFC 2317
+		// classless zone, which may live elsewhere, so it counts as taken.
+		for _, record := range records {
+			if !strings.EqualFold(record.Type, "PTR") && !strings.EqualFold(record.Type, "CNAME") {
+				continue
+			}
+			if ip, err := ParsePTRRecordName(strings.ToLower(record.Name)); err == nil {
+				used[ip.String()] = true
+			}
+		}
+	}
+
+	for _, zone := range expandStringList(d.Get("forward_zones").([]interface{})) {
+		records, err := client.PDNS.ListRecords(ctx, zone)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("couldn't fetch records of zone %s: %w", zone, err))
+		}
+		for _, record := range records {
+			if !strings.EqualFold(record.Type, "A") && !strings.EqualFold(record.Type, "AAAA") {
+				continue
+			}
+			if ip := net.ParseIP(record.Content); ip != nil {
+				used[ip.String()] = true
+			}
+		}
+	}
+
+	available, err := findAvailableIPs(block, used, excluded, d.Get("limit").(int), d.Get("include_network_and_broadcast").(bool))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	tflog.Debug(ctx, "Found available IPs", map[string]any{"used": len(used), "available": len(available)})
+
+	d.SetId(block.String())
+	if err := d.Set("reverse_zones", reverseZones); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting reverse_zones: %w", err))
+	}
+	if err := d.Set("ip_addresses", available); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting ip_addresses: %w", err))
+	}
+
+	return nil
+}
+
+// findReverseZonesForBlock returns, sorted by name, the reverse zones whose
+// block overlaps block: the zone that contains it as well as any more
+// specific zones inside it.
+func findReverseZonesForBlock(zones []ZoneInfo, block *net.IPNet) []string {
+	var matches []string
+	for _, zone := range zones {
+		cidr, err := ParseReverseZoneName(zoneBaseName(zone.Name))
+		if err != nil {
+			continue
+		}
+		_, zoneBlock, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+		if zoneBlock.Contains(block.IP) || block.Contains(zoneBlock.IP) {
+			matches = append(matches, zone.Name)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+// parseIPExclusions turns addresses and CIDR blocks into blocks; a single
+// address becomes a /32 or /128.
+func parseIPExclusions(values []string) ([]*net.IPNet, error) {
+	blocks := make([]*net.IPNet, 0, len(values))
+	for _, value := range values {
+		if !strings.Contains(value, "/") {
+			ip := net.ParseIP(value)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid exclude entry %q", value)
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			blocks = append(blocks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, block, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exclude entry %q: %w", value, err)
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+// findAvailableIPs walks block from its first address and returns the first
+// count addresses that are neither used nor excluded. Unless
+// includeNetworkAndBroadcast is set, the first and last address of an IPv4
+// block of /30 or larger are skipped.
+func findAvailableIPs(block *net.IPNet, used map[string]bool, excluded []*net.IPNet, count int, includeNetworkAndBroadcast bool) ([]string, error) {
+	ip := block.IP.To4()
+	if ip == nil {
+		ip = block.IP.To16()
+	}
+	ip = append(net.IP(nil), ip...)
+
+	ones, bits := block.Mask.Size()
+	skipEnds := !includeNetworkAndBroadcast && bits == 32 && ones <= 30
+
+	last := lastIP(block)
+	available := make([]string, 0, count)
+	for len(available) < count {
+		// An excluded block is skipped as a whole, so excluding most of a
+		// large IPv6 block does not mean walking every address in it.
+		if excludedBlock := ipExclusion(ip, excluded); excludedBlock != nil {
+			ip = lastIP(excludedBlock)
+		} else if !used[ip.String()] && !(skipEnds && (ip.Equal(block.IP) || ip.Equal(last))) {
+			available = append(available, ip.String())
+		}
+
+		if !block.Contains(ip) || ip.Equal(last) || bytesGreater(ip, last) {
+			break
+		}
+		ip = nextIP(ip)
+	}
+
+	if len(available) < count {
+		return nil, fmt.Errorf("only %d free addresses in %s, %d requested", len(available), block, count)
+	}
+	return available, nil
+}
+
+// ipExclusion returns the excluded block that contains ip, if any.
+func ipExclusion(ip net.IP, excluded []*net.IPNet) *net.IPNet {
+	for _, block := range excluded {
+		if block.Contains(ip) {
+			return block
+		}
+	}
+	return nil
+}
+
+// bytesGreater reports whether a is a higher address than b of the same length.
+func bytesGreater(a net.IP, b net.IP) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] > b[i]
+		}
+	}
+	return false
+}
+
+// nextIP returns the address after ip. The all-ones address wraps to zero,
+// which no block except the whole address space contains.
+func nextIP(ip net.IP) net.IP {
+	next := append(net.IP(nil), ip...)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		}
+	}
+	return next
+}
+
+// lastIP returns the highest address in block.
+func lastIP(block *net.IPNet) net.IP {
+	ip := block.IP.To4()
+	if ip == nil {
+		ip = block.IP.To16()
+	}
+	last := make(net.IP, len(ip))
+	for i := range ip {
+		last[i] = ip[i] | ^block.Mask[len(block.Mask)-len(ip)+i]
+	}
+	return last
+}
@@ -0,0 +1,155 @@
+package powerdns
+
+import (
+	"net"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func mustParseCIDR(t *testing.T, cidr string) *net.IPNet {
+	t.Helper()
+	_, block, err := net.ParseCIDR(cidr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return block
+}
+
+func TestFindAvailableIPs(t *testing.T) {
+	block := mustParseCIDR(t, "192.0.2.0/29")
+	used := map[string]bool{"192.0.2.1": true, "192.0.2.3": true}
+	excluded, err := parseIPExclusions([]string{"192.0.2.4"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	available, err := findAvailableIPs(block, used, excluded, 3, false)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"192.0.2.2", "192.0.2.5", "192.0.2.6"}, available)
+	}
+}
+
+func TestFindAvailableIPsNetworkAndBroadcast(t *testing.T) {
+	block := mustParseCIDR(t, "192.0.2.0/30")
+
+	available, err := findAvailableIPs(block, nil, nil, 2, false)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"192.0.2.1", "192.0.2.2"}, available)
+	}
+
+	available, err = findAvailableIPs(block, nil, nil, 4, true)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"192.0.2.0", "192.0.2.1", "192.0.2.2", "192.0.2.3"}, available)
+	}
+
+	// A /31 has no network or broadcast address to skip.
+	available, err = findAvailableIPs(mustParseCIDR(t, "192.0.2.4/31"), nil, nil, 2, false)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"192.0.2.4", "192.0.2.5"}, available)
+	}
+}
+
+func TestFindAvailableIPsExhausted(t *testing.T) {
+	block := mustParseCIDR(t, "192.0.2.0/30")
+	used := map[string]bool{"192.0.2.1": true}
+
+	_, err := findAvailableIPs(block, used, nil, 2, false)
+	assert.EqualError(t, err, "only 1 free addresses in 192.0.2.0/30, 2 requested")
+}
+
+func TestFindAvailableIPsIPv6SkipsExcludedBlocks(t *testing.T) {
+	block := mustParseCIDR(t, "2001:db8::/64")
+	excluded, err := parseIPExclusions([]string{"2001:db8::/65"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	used := map[string]bool{"2001:db8:0:0:8000::": true}
+
+	available, err := findAvailableIPs(block, used, excluded, 2, false)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"2001:db8::8000:0:0:1", "2001:db8::8000:0:0:2"}, available)
+	}
+}
+
+func TestFindReverseZonesForBlock(t *testing.T) {
+	zones := []ZoneInfo{
+		{Name: "example.com."},
+		{Name: "2.0.192.in-addr.arpa."},
+		{Name: "64/26.2.0.192.in-addr.arpa."},
+		{Name: "0.192.in-addr.arpa."},
+		{Name: "3.0.192.in-addr.arpa."},
+	}
+
+	assert.Equal(t, []string{
+		"0.192.in-addr.arpa.",
+		"2.0.192.in-addr.arpa.",
+		"64/26.2.0.192.in-addr.arpa.",
+	}, findReverseZonesForBlock(zones, mustParseCIDR(t, "192.0.2.0/24")))
+
+	assert.Equal(t, []string{
+		"0.192.in-addr.arpa.",
+		"2.0.192.in-addr.arpa.",
+	}, findReverseZonesForBlock(zones, mustParseCIDR(t, "192.0.2.128/25")))
+}
+
+func TestParseIPExclusionsInvalid(t *testing.T) {
+	_, err := parseIPExclusions([]string{"not-an-ip"})
+	assert.Error(t, err)
+}
+
+func TestAccPowerDNSAvailableIPsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPowerDNSAvailableIPsDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerdns_available_ips.test", "reverse_zones.#", "1"),
+					resource.TestCheckResourceAttr("data.powerdns_available_ips.test", "ip_addresses.#", "2"),
+					resource.TestCheckResourceAttr("data.powerdns_available_ips.test", "ip_addresses.0", "198.18.7.3"),
+					resource.TestCheckResourceAttr("data.powerdns_available_ips.test", "ip_addresses.1", "198.18.7.5"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPowerDNSAvailableIPsDataSource_NoReverseZone(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      `data "powerdns_available_ips" "test" { cidr = "198.51.100.0/24" }`,
+				ExpectError: regexp.MustCompile(`no reverse zone on the server covers 198\.51\.100\.0/24`),
+			},
+		},
+	})
+}
+
+const testAccPowerDNSAvailableIPsDataSourceConfig = `
+resource "powerdns_reverse_zone" "test" {
+  cidr        = "198.18.7.0/24"
+  kind        = "Master"
+  nameservers = ["ns1.example.com."]
+}
+
+resource "powerdns_ptr_record" "taken" {
+  ip_address   = "198.18.7.2"
+  hostname     = "taken.example.com."
+  ttl          = 300
+  reverse_zone = powerdns_reverse_zone.test.name
+}
+
+data "powerdns_available_ips" "test" {
+  cidr       = "198.18.7.0/24"
+  limit      = 2
+  exclude    = ["198.18.7.1", "198.18.7.4/32"]
+  depends_on = [powerdns_ptr_record.taken]
+}
+`
@@ -0,0 +1,140 @@
+package powerdns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourcePDNSCatalogMembers lists the member zones of a catalog zone.
+func dataSourcePDNSCatalogMembers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePDNSCatalogMembersRead,
+
+		Schema: map[string]*schema.Schema{
+			"catalog": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: ValidateZoneName,
+				Description:  "The name of the catalog zone.",
+			},
+			"kind": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The kind of the catalog zone.",
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The names of the member zones, sorted.",
+			},
+			"members": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The member zones, sorted by name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the member zone.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the member zone.",
+						},
+						"kind": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The kind of the member zone.",
+						},
+						"unique": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The unique label of the member in the catalog zone, when the catalog zone records are available.",
+						},
+						"groups": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The group properties of the member, when the catalog zone records are available.",
+						},
+						"coo": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The change of ownership property of the member, when the catalog zone records are available.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePDNSCatalogMembersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderClients)
+
+	catalog := d.Get("catalog").(string)
+	ctx = tflog.SetField(ctx, "catalog", catalog)
+	tflog.Info(ctx, "Reading catalog members data source")
+
+	zones, err := client.PDNS.ListZones(ctx)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't list zones: %w", err))
+	}
+	catalogZone, found := findCatalogZone(zones, catalog)
+	if !found {
+		return diag.FromErr(fmt.Errorf("catalog zone %s does not exist", catalog))
+	}
+	if !isCatalogZoneKind(catalogZone.Kind) {
+		return diag.FromErr(fmt.Errorf("zone %s is a %s zone, not a catalog zone", catalog, catalogZone.Kind))
+	}
+
+	// The member properties only exist in the records of the catalog zone.
+	catalogWithRRSets, err := client.PDNS.GetZoneWithRRsets(ctx, catalogZone.Name)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't fetch catalog zone %s: %w", catalog, err))
+	}
+	properties := parseCatalogMemberProperties(catalogZone.Name, catalogWithRRSets.ResourceRecordSets)
+
+	members := catalogMembers(zones, catalogZone.Name)
+	names := make([]string, 0, len(members))
+	flattened := make([]map[string]interface{}, 0, len(members))
+	for _, zone := range members {
+		member := map[string]interface{}{
+			"id":     zone.ID,
+			"name":   zone.Name,
+			"kind":   zone.Kind,
+			"unique": "",
+			"groups": []string{},
+			"coo":    "",
+		}
+		if p := properties[strings.ToLower(zone.Name)]; p != nil {
+			member["unique"] = p.unique
+			member["groups"] = p.groups
+			member["coo"] = p.coo
+		}
+		names = append(names, zone.Name)
+		flattened = append(flattened, member)
+	}
+
+	d.SetId(catalogZone.Name)
+	if err := d.Set("kind", catalogZone.Kind); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting kind: %w", err))
+	}
+	if err := d.Set("names", names); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting names: %w", err))
+	}
+	if err := d.Set("members", flattened); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting members: %w", err))
+	}
+
+	tflog.Info(ctx, "Listed catalog members", map[string]any{"members": len(members)})
+	return nil
+}
@@ -0,0 +1,51 @@
+package powerdns
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourcePDNSCatalogMembers(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSCatalogZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourcePDNSCatalogMembersConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerdns_catalog_members.test", "kind", "Producer"),
+					resource.TestCheckResourceAttr("data.powerdns_catalog_members.test", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.powerdns_catalog_members.test", "names.0", "one.catalog-members.sysa.xyz."),
+					resource.TestCheckResourceAttr("data.powerdns_catalog_members.test", "members.1.name", "two.catalog-members.sysa.xyz."),
+					resource.TestCheckResourceAttr("data.powerdns_catalog_members.test", "members.1.kind", "Native"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourcePDNSCatalogMembersConfig = `
+resource "powerdns_catalog_zone" "test" {
+	name = "catalog-members.sysa.xyz."
+	kind = "Producer"
+}
+
+resource "powerdns_zone" "one" {
+	name    = "one.catalog-members.sysa.xyz."
+	kind    = "Master"
+	catalog = powerdns_catalog_zone.test.name
+}
+
+resource "powerdns_zone" "two" {
+	name    = "two.catalog-members.sysa.xyz."
+	kind    = "Native"
+	catalog = powerdns_catalog_zone.test.name
+}
+
+data "powerdns_catalog_members" "test" {
+	catalog = powerdns_catalog_zone.test.name
+
+	depends_on = [powerdns_zone.one, powerdns_zone.two]
+}`
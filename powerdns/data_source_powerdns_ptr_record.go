@@ -0,0 +1,118 @@
+package powerdns
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// dataSourcePDNSPTRRecord looks up the PTR record of a single address. Without
+// reverse_zone it searches the zones on the server for the one covering the
+// address, and it fails when that zone has no PTR record for it.
+func dataSourcePDNSPTRRecord() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePDNSPTRRecordRead,
+
+		Schema: map[string]*schema.Schema{
+			"ip_address": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.Any(validation.IsIPv4Address, validation.IsIPv6Address),
+				Description:  "The IP address to look up the PTR record of (IPv4 or IPv6).",
+			},
+			"reverse_zone": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: ValidateFQDN,
+				Description:  "The reverse zone holding the PTR record. If omitted, the most specific reverse zone on the server that covers ip_address is used.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The fully qualified name of the PTR record (e.g., '10.2.0.192.in-addr.arpa.').",
+			},
+			"hostname": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The hostname the address points to. If the PTR RRset has several records, the first hostname in sorted order.",
+			},
+			"hostnames": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "All hostnames the address points to, sorted.",
+			},
+			"ttl": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The TTL of the PTR record.",
+			},
+		},
+	}
+}
+
+func dataSourcePDNSPTRRecordRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderClients)
+
+	ipAddress := d.Get("ip_address").(string)
+	reverseZone := d.Get("reverse_zone").(string)
+	ctx = tflog.SetField(ctx, "ip_address", ipAddress)
+	tflog.Info(ctx, "Reading PTR record data source")
+
+	if reverseZone == "" {
+		zones, err := client.PDNS.ListZones(ctx)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("couldn't list zones to find the reverse zone of %s: %w", ipAddress, err))
+		}
+		if reverseZone, err = findReverseZoneForIP(zones, ipAddress); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	ctx = tflog.SetField(ctx, "reverse_zone", reverseZone)
+
+	ptrName, err := GetPTRRecordFQDN(ipAddress, reverseZone)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to determine PTR record name: %w", err))
+	}
+
+	records, err := client.PDNS.ListRecordsInRRSet(ctx, reverseZone, ptrName, "PTR")
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't fetch PTR record %s: %w", ptrName, err))
+	}
+	if len(records) == 0 {
+		return diag.FromErr(fmt.Errorf("PTR record for %s not found in zone %s", ipAddress, reverseZone))
+	}
+
+	hostnames := make([]string, 0, len(records))
+	for _, record := range records {
+		hostnames = append(hostnames, record.Content)
+	}
+	sort.Strings(hostnames)
+
+	d.SetId(records[0].ID())
+
+	if err := d.Set("reverse_zone", reverseZone); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting reverse_zone: %w", err))
+	}
+	if err := d.Set("name", ptrName); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting name: %w", err))
+	}
+	if err := d.Set("hostname", hostnames[0]); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting hostname: %w", err))
+	}
+	if err := d.Set("hostnames", hostnames); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting hostnames: %w", err))
+	}
+	if err := d.Set("ttl", records[0].TTL); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting ttl: %w", err))
+	}
+
+	tflog.Info(ctx, "Successfully retrieved PTR record", map[string]any{"ptr_name": ptrName})
+	return nil
+}
@@ -0,0 +1,82 @@
+package powerdns
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// ptrRecordTestClient serves a zone list with a /24 reverse zone and a
+// classless child of it. records maps zone IDs to the zones served.
+func ptrRecordTestClient(t *testing.T, records map[string]string) *PowerDNSClient {
+	return newTestClient(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		if r.URL.Path == "/api/v1/servers/localhost/zones" {
+			return jsonResponse(http.StatusOK, `[
+				{"id": "example.com.", "name": "example.com.", "kind": "Native"},
+				{"id": "2.0.192.in-addr.arpa.", "name": "2.0.192.in-addr.arpa.", "kind": "Native"},
+				{"id": "0=2F25.2.0.192.in-addr.arpa.", "name": "0/25.2.0.192.in-addr.arpa.", "kind": "Native"}
+			]`), nil
+		}
+		for zone, body := range records {
+			if r.URL.Path == "/api/v1/servers/localhost/zones/"+zone {
+				return jsonResponse(http.StatusOK, body), nil
+			}
+		}
+		t.Errorf("unexpected request: %s", r.URL.Path)
+		return jsonResponse(http.StatusNotFound, `{"error": "Not Found"}`), nil
+	})
+}
+
+func TestDataSourcePDNSPTRRecordDiscoversReverseZone(t *testing.T) {
+	client := ptrRecordTestClient(t, map[string]string{
+		"0=2F25.2.0.192.in-addr.arpa.": `{"name": "0/25.2.0.192.in-addr.arpa.", "rrsets": [
+			{"name": "10.0/25.2.0.192.in-addr.arpa.", "type": "PTR", "ttl": 300, "records": [
+				{"content": "web.example.com."}, {"content": "app.example.com."}
+			]}
+		]}`,
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourcePDNSPTRRecord().Schema, map[string]interface{}{
+		"ip_address": "192.0.2.10",
+	})
+	diags := dataSourcePDNSPTRRecordRead(context.Background(), d, &ProviderClients{PDNS: client})
+	if !assert.False(t, diags.HasError(), diags) {
+		return
+	}
+
+	// The classless zone holding the address wins over its parent /24.
+	assert.Equal(t, "0/25.2.0.192.in-addr.arpa.", d.Get("reverse_zone"))
+	assert.Equal(t, "10.0/25.2.0.192.in-addr.arpa.", d.Get("name"))
+	assert.Equal(t, "app.example.com.", d.Get("hostname"))
+	assert.Equal(t, []interface{}{"app.example.com.", "web.example.com."}, d.Get("hostnames"))
+	assert.Equal(t, 300, d.Get("ttl"))
+}
+
+func TestDataSourcePDNSPTRRecordNotFound(t *testing.T) {
+	client := ptrRecordTestClient(t, map[string]string{
+		"2.0.192.in-addr.arpa.": `{"name": "2.0.192.in-addr.arpa.", "rrsets": [
+			{"name": "2.0.192.in-addr.arpa.", "type": "NS", "ttl": 3600, "records": [{"content": "ns1.example.com."}]}
+		]}`,
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourcePDNSPTRRecord().Schema, map[string]interface{}{
+		"ip_address": "192.0.2.200",
+	})
+	diags := dataSourcePDNSPTRRecordRead(context.Background(), d, &ProviderClients{PDNS: client})
+	if assert.True(t, diags.HasError()) {
+		assert.Equal(t, "PTR record for 192.0.2.200 not found in zone 2.0.192.in-addr.arpa.", diags[0].Summary)
+	}
+	assert.Empty(t, d.Id())
+
+	// An address outside every reverse zone on the server fails the lookup.
+	d = schema.TestResourceDataRaw(t, dataSourcePDNSPTRRecord().Schema, map[string]interface{}{
+		"ip_address": "198.51.100.1",
+	})
+	diags = dataSourcePDNSPTRRecordRead(context.Background(), d, &ProviderClients{PDNS: client})
+	assert.True(t, diags.HasError())
+	assert.Empty(t, d.Id())
+}
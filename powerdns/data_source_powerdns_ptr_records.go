@@ -0,0 +1,184 @@
+package powerdns
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// dataSourcePDNSPTRRecords lists the PTR records of a reverse zone, or of
+// every reverse zone on the server that overlaps a block.
+func dataSourcePDNSPTRRecords() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePDNSPTRRecordsRead,
+
+		Schema: map[string]*schema.Schema{
+			"reverse_zone": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"reverse_zone", "cidr"},
+				ValidateFunc: ValidateFQDN,
+				Description:  "The reverse zone to list the PTR records of (e.g., '2.0.192.in-addr.arpa.').",
+			},
+			"cidr": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"reverse_zone", "cidr"},
+				ValidateFunc: validation.IsCIDR,
+				Description:  "An IPv4 or IPv6 block to list the PTR records of. Every reverse zone on the server that overlaps the block is read.",
+			},
+			"reverse_zones": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The reverse zones that were read.",
+			},
+			"records": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The PTR records, sorted by address and hostname. An address with several hostnames has one entry per hostname.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ip_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The IP address decoded from the PTR record name.",
+						},
+						"hostname": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The hostname the address points to.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The fully qualified name of the PTR record.",
+						},
+						"reverse_zone": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The reverse zone holding the PTR record.",
+						},
+						"ttl": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The TTL of the PTR record.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ptrRecordEntry is a PTR record decoded back into the address it names.
+type ptrRecordEntry struct {
+	ip          net.IP
+	hostname    string
+	name        string
+	reverseZone string
+	ttl         int
+}
+
+func dataSourcePDNSPTRRecordsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderClients)
+
+	var block *net.IPNet
+	reverseZones := []string{d.Get("reverse_zone").(string)}
+	id := reverseZones[0]
+
+	if cidr := d.Get("cidr").(string); cidr != "" {
+		var err error
+		if _, block, err = net.ParseCIDR(cidr); err != nil {
+			return diag.FromErr(fmt.Errorf("invalid cidr: %w", err))
+		}
+		id = block.String()
+
+		zones, err := client.PDNS.ListZones(ctx)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("couldn't list zones: %w", err))
+		}
+		if reverseZones = findReverseZonesForBlock(zones, block); len(reverseZones) == 0 {
+			return diag.FromErr(fmt.Errorf("no reverse zone on the server covers %s", block))
+		}
+	}
+
+	ctx = tflog.SetField(ctx, "reverse_zones", reverseZones)
+	tflog.Info(ctx, "Reading PTR records data source")
+
+	var entries []ptrRecordEntry
+	for _, reverseZone := range reverseZones {
+		records, err := client.PDNS.ListRecords(ctx, reverseZone)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("couldn't fetch records of zone %s: %w", reverseZone, err))
+		}
+		entries = append(entries, ptrRecordEntries(records, reverseZone, block)...)
+	}
+	sortPTRRecordEntries(entries)
+
+	flattened := make([]map[string]interface{}, 0, len(entries))
+	for _, entry := range entries {
+		flattened = append(flattened, map[string]interface{}{
+			"ip_address":   entry.ip.String(),
+			"hostname":     entry.hostname,
+			"name":         entry.name,
+			"reverse_zone": entry.reverseZone,
+			"ttl":          entry.ttl,
+		})
+	}
+
+	d.SetId(id)
+	if err := d.Set("reverse_zones", reverseZones); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting reverse_zones: %w", err))
+	}
+	if err := d.Set("records", flattened); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting records: %w", err))
+	}
+
+	tflog.Info(ctx, "Successfully listed PTR records", map[string]any{"record_count": len(entries)})
+	return nil
+}
+
+// ptrRecordEntries decodes the PTR records of a reverse zone. Records whose
+// name is not an address, and with a non-nil block those outside it, are
+// skipped.
+func ptrRecordEntries(records []Record, reverseZone string, block *net.IPNet) []ptrRecordEntry {
+	var entries []ptrRecordEntry
+	for _, record := range records {
+		if !strings.EqualFold(record.Type, "PTR") {
+			continue
+		}
+		ip, err := ParsePTRRecordName(strings.ToLower(record.Name))
+		if err != nil || (block != nil && !block.Contains(ip)) {
+			continue
+		}
+		entries = append(entries, ptrRecordEntry{
+			ip:          ip,
+			hostname:    record.Content,
+			name:        record.Name,
+			reverseZone: reverseZone,
+			ttl:         record.TTL,
+		})
+	}
+	return entries
+}
+
+// sortPTRRecordEntries sorts entries numerically by address, and then
+// by hostname.
+func sortPTRRecordEntries(entries []ptrRecordEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i].ip.To16(), entries[j].ip.To16()
+		if c := bytes.Compare(a, b); c != 0 {
+			return c < 0
+		}
+		return entries[i].hostname < entries[j].hostname
+	})
+}
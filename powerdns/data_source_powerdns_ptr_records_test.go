@@ -0,0 +1,130 @@
+package powerdns
+
+import (
+	"net"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPTRRecordEntries(t *testing.T) {
+	records := []Record{
+		{Name: "2.0.192.in-addr.arpa.", Type: "NS", Content: "ns1.example.com."},
+		{Name: "10.2.0.192.in-addr.arpa.", Type: "PTR", Content: "web.example.com.", TTL: 300},
+		{Name: "9.2.0.192.in-addr.arpa.", Type: "PTR", Content: "mail.example.com.", TTL: 600},
+		{Name: "200.2.0.192.in-addr.arpa.", Type: "PTR", Content: "far.example.com.", TTL: 300},
+		{Name: "10.2.0.192.in-addr.arpa.", Type: "PTR", Content: "app.example.com.", TTL: 300},
+		{Name: "_tag.2.0.192.in-addr.arpa.", Type: "PTR", Content: "not-an-address.example.com."},
+	}
+	_, block, _ := net.ParseCIDR("192.0.2.0/25")
+
+	entries := ptrRecordEntries(records, "2.0.192.in-addr.arpa.", block)
+	sortPTRRecordEntries(entries)
+
+	var got []string
+	for _, entry := range entries {
+		got = append(got, entry.ip.String()+" "+entry.hostname)
+	}
+	assert.Equal(t, []string{
+		"192.0.2.9 mail.example.com.",
+		"192.0.2.10 app.example.com.",
+		"192.0.2.10 web.example.com.",
+	}, got)
+	assert.Equal(t, 600, entries[0].ttl)
+	assert.Equal(t, "2.0.192.in-addr.arpa.", entries[0].reverseZone)
+
+	// Without a block every PTR record with an address name is returned.
+	assert.Len(t, ptrRecordEntries(records, "2.0.192.in-addr.arpa.", nil), 4)
+}
+
+func TestAccPowerDNSPTRRecordsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPowerDNSPTRRecordsDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerdns_ptr_record.web", "hostname", "web.example.com."),
+					resource.TestCheckResourceAttr("data.powerdns_ptr_record.web", "reverse_zone", "45.18.198.in-addr.arpa."),
+					resource.TestCheckResourceAttr("data.powerdns_ptr_record.web", "name", "10.45.18.198.in-addr.arpa."),
+					resource.TestCheckResourceAttr("data.powerdns_ptr_record.web", "ttl", "300"),
+					resource.TestCheckResourceAttr("data.powerdns_ptr_records.zone", "records.#", "2"),
+					resource.TestCheckResourceAttr("data.powerdns_ptr_records.zone", "records.0.ip_address", "198.18.45.9"),
+					resource.TestCheckResourceAttr("data.powerdns_ptr_records.zone", "records.0.hostname", "mail.example.com."),
+					resource.TestCheckResourceAttr("data.powerdns_ptr_records.zone", "records.1.ip_address", "198.18.45.10"),
+					resource.TestCheckResourceAttr("data.powerdns_ptr_records.block", "reverse_zones.0", "45.18.198.in-addr.arpa."),
+					resource.TestCheckResourceAttr("data.powerdns_ptr_records.block", "records.#", "1"),
+					resource.TestCheckResourceAttr("data.powerdns_ptr_records.block", "records.0.hostname", "web.example.com."),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPowerDNSPTRRecordDataSource_NotFound(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccPowerDNSPTRRecordDataSourceConfig_NotFound,
+				ExpectError: regexp.MustCompile(`PTR record for 198\.18\.46\.1 not found`),
+			},
+		},
+	})
+}
+
+const testAccPowerDNSPTRRecordsDataSourceConfig = `
+resource "powerdns_reverse_zone" "test" {
+  cidr        = "198.18.45.0/24"
+  kind        = "Master"
+  nameservers = ["ns1.example.com."]
+}
+
+resource "powerdns_ptr_record" "web" {
+  ip_address   = "198.18.45.10"
+  hostname     = "web.example.com."
+  ttl          = 300
+  reverse_zone = powerdns_reverse_zone.test.name
+}
+
+resource "powerdns_ptr_record" "mail" {
+  ip_address   = "198.18.45.9"
+  hostname     = "mail.example.com."
+  ttl          = 300
+  reverse_zone = powerdns_reverse_zone.test.name
+}
+
+data "powerdns_ptr_record" "web" {
+  ip_address = "198.18.45.10"
+  depends_on = [powerdns_ptr_record.web]
+}
+
+data "powerdns_ptr_records" "zone" {
+  reverse_zone = powerdns_reverse_zone.test.name
+  depends_on   = [powerdns_ptr_record.web, powerdns_ptr_record.mail]
+}
+
+data "powerdns_ptr_records" "block" {
+  cidr       = "198.18.45.10/31"
+  depends_on = [powerdns_ptr_record.web, powerdns_ptr_record.mail]
+}
+`
+
+const testAccPowerDNSPTRRecordDataSourceConfig_NotFound = `
+resource "powerdns_reverse_zone" "test" {
+  cidr        = "198.18.46.0/24"
+  kind        = "Master"
+  nameservers = ["ns1.example.com."]
+}
+
+data "powerdns_ptr_record" "missing" {
+  ip_address   = "198.18.46.1"
+  reverse_zone = powerdns_reverse_zone.test.name
+}
+`
@@ -0,0 +1,363 @@
+package powerdns
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourcePDNSReverseConsistency cross-checks the A and AAAA records of a set
+// of forward zones against the PTR records of a set of reverse zones.
+func dataSourcePDNSReverseConsistency() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePDNSReverseConsistencyRead,
+
+		Schema: map[string]*schema.Schema{
+			"forward_zones": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: ValidateZoneName,
+				},
+				Description: "The forward zones whose A and AAAA records are checked.",
+			},
+			"reverse_zones": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: ValidateZoneName,
+				},
+				Description: "The reverse zones whose PTR records are checked.",
+			},
+			"missing_ptrs": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "A and AAAA records for an address in one of the reverse zones that has no PTR record.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the A or AAAA record.",
+						},
+						"ip_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The address of the record.",
+						},
+						"zone": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The forward zone holding the record.",
+						},
+						"reverse_zone": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The reverse zone the PTR record belongs in.",
+						},
+						"ptr_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The fully qualified name the PTR record would have.",
+						},
+					},
+				},
+			},
+			"orphan_ptrs": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "PTR records pointing at a name in one of the forward zones that has no A or AAAA record.",
+				Elem: &schema.Resource{
+					Schema: consistencyPTRSchema(),
+				},
+			},
+			"mismatched_ptrs": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "PTR records pointing at a name in one of the forward zones whose A and AAAA records do not include the address.",
+				Elem: &schema.Resource{
+					Schema: func() map[string]*schema.Schema {
+						s := consistencyPTRSchema()
+						s["hostname_addresses"] = &schema.Schema{
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The addresses the hostname resolves to.",
+						}
+						return s
+					}(),
+				},
+			},
+			"consistent": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether missing_ptrs, orphan_ptrs and mismatched_ptrs are all empty.",
+			},
+		},
+	}
+}
+
+// consistencyPTRSchema describes a PTR record reported by the consistency
+// check.
+func consistencyPTRSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The fully qualified name of the PTR record.",
+		},
+		"ip_address": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The IP address decoded from the PTR record name.",
+		},
+		"hostname": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The hostname the PTR record points to.",
+		},
+		"reverse_zone": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The reverse zone holding the PTR record.",
+		},
+	}
+}
+
+// missingPTR is a forward record whose address has no PTR record.
+type missingPTR struct {
+	name        string
+	ip          net.IP
+	zone        string
+	reverseZone string
+	ptrName     string
+}
+
+// mismatchedPTR is a PTR record whose hostname does not resolve back to the
+// address.
+type mismatchedPTR struct {
+	ptrRecordEntry
+	addresses []string
+}
+
+// reverseConsistencyReport holds the findings of checkReverseConsistency.
+type reverseConsistencyReport struct {
+	missing    []missingPTR
+	orphans    []ptrRecordEntry
+	mismatched []mismatchedPTR
+}
+
+func dataSourcePDNSReverseConsistencyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderClients)
+
+	forwardZones := expandStringList(d.Get("forward_zones").([]interface{}))
+	reverseZones := expandStringList(d.Get("reverse_zones").([]interface{}))
+	ctx = tflog.SetField(ctx, "forward_zones", forwardZones)
+	ctx = tflog.SetField(ctx, "reverse_zones", reverseZones)
+	tflog.Info(ctx, "Reading reverse consistency data source")
+
+	forward := make(map[string][]Record, len(forwardZones))
+	for _, zone := range forwardZones {
+		records, err := consistencyZoneRecords(ctx, client.PDNS, zone)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		forward[zone] = records
+	}
+	reverse := make(map[string][]Record, len(reverseZones))
+	for _, zone := range reverseZones {
+		records, err := consistencyZoneRecords(ctx, client.PDNS, zone)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		reverse[zone] = records
+	}
+
+	report := checkReverseConsistency(forward, reverse)
+
+	missing := make([]map[string]interface{}, 0, len(report.missing))
+	for _, m := range report.missing {
+		missing = append(missing, map[string]interface{}{
+			"name":         m.name,
+			"ip_address":   m.ip.String(),
+			"zone":         m.zone,
+			"reverse_zone": m.reverseZone,
+			"ptr_name":     m.ptrName,
+		})
+	}
+	orphans := make([]map[string]interface{}, 0, len(report.orphans))
+	for _, entry := range report.orphans {
+		orphans = append(orphans, flattenConsistencyPTR(entry))
+	}
+	mismatched := make([]map[string]interface{}, 0, len(report.mismatched))
+	for _, m := range report.mismatched {
+		flattened := flattenConsistencyPTR(m.ptrRecordEntry)
+		flattened["hostname_addresses"] = m.addresses
+		mismatched = append(mismatched, flattened)
+	}
+	consistent := len(missing) == 0 && len(orphans) == 0 && len(mismatched) == 0
+
+	tflog.Info(ctx, "Checked forward and reverse records", map[string]any{
+		"missing":    len(missing),
+		"orphans":    len(orphans),
+		"mismatched": len(mismatched),
+	})
+
+	d.SetId(strings.Join(forwardZones, ",") + ";" + strings.Join(reverseZones, ","))
+	if err := d.Set("missing_ptrs", missing); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting missing_ptrs: %w", err))
+	}
+	if err := d.Set("orphan_ptrs", orphans); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting orphan_ptrs: %w", err))
+	}
+	if err := d.Set("mismatched_ptrs", mismatched); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting mismatched_ptrs: %w", err))
+	}
+	if err := d.Set("consistent", consistent); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting consistent: %w", err))
+	}
+
+	return nil
+}
+
+// consistencyZoneRecords fetches the records of zone. Unlike ListRecords it
+// fails when the zone does not exist, so a mistyped or deleted zone cannot
+// pass the check as an empty one.
+func consistencyZoneRecords(ctx context.Context, client *PowerDNSClient, zone string) ([]Record, error) {
+	zoneInfo, err := client.GetZoneWithRRsets(ctx, zone)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't fetch records of zone %s: %w", zone, err)
+	}
+
+	records := zoneInfo.Records
+	for _, rrSet := range zoneInfo.ResourceRecordSets {
+		records = append(records, recordsFromRRSet(&rrSet)...)
+	}
+	return records, nil
+}
+
+func flattenConsistencyPTR(entry ptrRecordEntry) map[string]interface{} {
+	return map[string]interface{}{
+		"name":         entry.name,
+		"ip_address":   entry.ip.String(),
+		"hostname":     entry.hostname,
+		"reverse_zone": entry.reverseZone,
+	}
+}
+
+// checkReverseConsistency compares the forward records, keyed by zone, with
+// the reverse records, keyed by zone. Disabled records are ignored. Forward
+// records for addresses outside the reverse zones, and PTR records pointing
+// outside the forward zones, cannot be checked and are not reported.
+func checkReverseConsistency(forward map[string][]Record, reverse map[string][]Record) reverseConsistencyReport {
+	var report reverseConsistencyReport
+
+	reverseZones := make([]ZoneInfo, 0, len(reverse))
+	var ptrs []ptrRecordEntry
+	ptrsByIP := map[string][]ptrRecordEntry{}
+	for _, zone := range sortedKeys(reverse) {
+		reverseZones = append(reverseZones, ZoneInfo{Name: zone})
+		for _, entry := range ptrRecordEntries(enabledRecords(reverse[zone]), zone, nil) {
+			entry.hostname = strings.ToLower(entry.hostname)
+			ptrs = append(ptrs, entry)
+			ptrsByIP[entry.ip.String()] = append(ptrsByIP[entry.ip.String()], entry)
+		}
+	}
+	sortPTRRecordEntries(ptrs)
+
+	forwardZones := make([]string, 0, len(forward))
+	addressesByName := map[string][]string{}
+	for _, zone := range sortedKeys(forward) {
+		forwardZones = append(forwardZones, zone)
+		for _, record := range enabledRecords(forward[zone]) {
+			if !strings.EqualFold(record.Type, "A") && !strings.EqualFold(record.Type, "AAAA") {
+				continue
+			}
+			ip := net.ParseIP(record.Content)
+			if ip == nil {
+				continue
+			}
+			name := strings.ToLower(record.Name)
+			addressesByName[name] = append(addressesByName[name], ip.String())
+
+			if len(ptrsByIP[ip.String()]) > 0 {
+				continue
+			}
+			reverseZone, err := findReverseZoneForIP(reverseZones, ip.String())
+			if err != nil {
+				continue
+			}
+			ptrName, err := GetPTRRecordFQDN(ip.String(), reverseZone)
+			if err != nil {
+				continue
+			}
+			report.missing = append(report.missing, missingPTR{
+				name:        record.Name,
+				ip:          ip,
+				zone:        zone,
+				reverseZone: reverseZone,
+				ptrName:     ptrName,
+			})
+		}
+	}
+	sort.SliceStable(report.missing, func(i, j int) bool {
+		if c := bytes.Compare(report.missing[i].ip.To16(), report.missing[j].ip.To16()); c != 0 {
+			return c < 0
+		}
+		return report.missing[i].name < report.missing[j].name
+	})
+
+	for _, entry := range ptrs {
+		inForwardZones := false
+		for _, zone := range forwardZones {
+			if recordNameInZone(entry.hostname, zone) {
+				inForwardZones = true
+				break
+			}
+		}
+		if !inForwardZones {
+			continue
+		}
+
+		addresses := addressesByName[entry.hostname]
+		if len(addresses) == 0 {
+			report.orphans = append(report.orphans, entry)
+			continue
+		}
+		resolves := false
+		for _, address := range addresses {
+			if address == entry.ip.String() {
+				resolves = true
+				break
+			}
+		}
+		if !resolves {
+			sort.Strings(addresses)
+			report.mismatched = append(report.mismatched, mismatchedPTR{ptrRecordEntry: entry, addresses: addresses})
+		}
+	}
+
+	return report
+}
+
+// enabledRecords returns the records that are not disabled.
+func enabledRecords(records []Record) []Record {
+	enabled := make([]Record, 0, len(records))
+	for _, record := range records {
+		if !record.Disabled {
+			enabled = append(enabled, record)
+		}
+	}
+	return enabled
+}
@@ -0,0 +1,183 @@
+package powerdns
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckReverseConsistency(t *testing.T) {
+	forward := map[string][]Record{
+		"example.com.": {
+			{Name: "web.example.com.", Type: "A", Content: "192.0.2.10"},
+			{Name: "mail.example.com.", Type: "A", Content: "192.0.2.9"},
+			{Name: "db.example.com.", Type: "AAAA", Content: "2001:db8::5"},
+			{Name: "old.example.com.", Type: "A", Content: "192.0.2.30", Disabled: true},
+			{Name: "external.example.com.", Type: "A", Content: "198.51.100.1"},
+			{Name: "example.com.", Type: "NS", Content: "ns1.example.com."},
+		},
+	}
+	reverse := map[string][]Record{
+		"2.0.192.in-addr.arpa.": {
+			{Name: "10.2.0.192.in-addr.arpa.", Type: "PTR", Content: "web.example.com."},
+			{Name: "11.2.0.192.in-addr.arpa.", Type: "PTR", Content: "MAIL.example.com."},
+			{Name: "20.2.0.192.in-addr.arpa.", Type: "PTR", Content: "gone.example.com."},
+			{Name: "21.2.0.192.in-addr.arpa.", Type: "PTR", Content: "host.example.net."},
+		},
+		"8.b.d.0.1.0.0.2.ip6.arpa.": {},
+	}
+
+	report := checkReverseConsistency(forward, reverse)
+
+	// mail has a PTR for another address, db has none at all; the disabled
+	// record and the one outside the reverse zones are not reported.
+	if assert.Len(t, report.missing, 2) {
+		assert.Equal(t, "mail.example.com.", report.missing[0].name)
+		assert.Equal(t, "9.2.0.192.in-addr.arpa.", report.missing[0].ptrName)
+		assert.Equal(t, "2.0.192.in-addr.arpa.", report.missing[0].reverseZone)
+		assert.Equal(t, "db.example.com.", report.missing[1].name)
+		assert.Equal(t, "8.b.d.0.1.0.0.2.ip6.arpa.", report.missing[1].reverseZone)
+		assert.Equal(t, "example.com.", report.missing[1].zone)
+	}
+
+	// The PTR for a hostname outside the forward zones is not reported.
+	if assert.Len(t, report.orphans, 1) {
+		assert.Equal(t, "192.0.2.20", report.orphans[0].ip.String())
+		assert.Equal(t, "gone.example.com.", report.orphans[0].hostname)
+	}
+
+	if assert.Len(t, report.mismatched, 1) {
+		assert.Equal(t, "192.0.2.11", report.mismatched[0].ip.String())
+		assert.Equal(t, "mail.example.com.", report.mismatched[0].hostname)
+		assert.Equal(t, []string{"192.0.2.9"}, report.mismatched[0].addresses)
+	}
+}
+
+func TestCheckReverseConsistency_Consistent(t *testing.T) {
+	forward := map[string][]Record{
+		"example.com.": {{Name: "web.example.com.", Type: "A", Content: "192.0.2.10"}},
+	}
+	reverse := map[string][]Record{
+		"2.0.192.in-addr.arpa.": {{Name: "10.2.0.192.in-addr.arpa.", Type: "PTR", Content: "web.example.com."}},
+	}
+
+	report := checkReverseConsistency(forward, reverse)
+	assert.Empty(t, report.missing)
+	assert.Empty(t, report.orphans)
+	assert.Empty(t, report.mismatched)
+}
+
+func TestReverseConsistencyMissingZone(t *testing.T) {
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/api/v1/servers/localhost/zones/example.com." {
+			return jsonResponse(http.StatusOK, `{"name": "example.com.", "rrsets": [
+				{"name": "web.example.com.", "type": "A", "ttl": 300, "records": [{"content": "192.0.2.10"}]}
+			]}`), nil
+		}
+		return jsonResponse(http.StatusNotFound, `{"error": "Could not find domain '2.0.192.in-addr.arpa.'"}`), nil
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourcePDNSReverseConsistency().Schema, map[string]interface{}{
+		"forward_zones": []interface{}{"example.com."},
+		"reverse_zones": []interface{}{"2.0.192.in-addr.arpa."},
+	})
+	diags := dataSourcePDNSReverseConsistencyRead(context.Background(), d, &ProviderClients{PDNS: client})
+	if assert.True(t, diags.HasError()) {
+		assert.Contains(t, diags[0].Summary, "couldn't fetch records of zone 2.0.192.in-addr.arpa.")
+	}
+	assert.Empty(t, d.Id())
+}
+
+func TestAccPowerDNSReverseConsistencyDataSource(t *testing.T) {
+	resourceName := "data.powerdns_reverse_consistency.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPowerDNSReverseConsistencyDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "consistent", "false"),
+					resource.TestCheckResourceAttr(resourceName, "missing_ptrs.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "missing_ptrs.0.name", "nopr.consistency.sysa.xyz."),
+					resource.TestCheckResourceAttr(resourceName, "missing_ptrs.0.ptr_name", "11.50.18.198.in-addr.arpa."),
+					resource.TestCheckResourceAttr(resourceName, "orphan_ptrs.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "orphan_ptrs.0.hostname", "gone.consistency.sysa.xyz."),
+					resource.TestCheckResourceAttr(resourceName, "mismatched_ptrs.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "mismatched_ptrs.0.ip_address", "198.18.50.13"),
+					resource.TestCheckResourceAttr(resourceName, "mismatched_ptrs.0.hostname_addresses.0", "198.18.50.10"),
+				),
+			},
+		},
+	})
+}
+
+const testAccPowerDNSReverseConsistencyDataSourceConfig = `
+resource "powerdns_zone" "forward" {
+  name        = "consistency.sysa.xyz."
+  kind        = "Native"
+  nameservers = ["ns1.sysa.xyz."]
+}
+
+resource "powerdns_reverse_zone" "reverse" {
+  cidr        = "198.18.50.0/24"
+  kind        = "Native"
+  nameservers = ["ns1.sysa.xyz."]
+}
+
+resource "powerdns_record" "web" {
+  zone    = powerdns_zone.forward.name
+  name    = "web.consistency.sysa.xyz."
+  type    = "A"
+  ttl     = 300
+  records = ["198.18.50.10"]
+}
+
+resource "powerdns_record" "nopr" {
+  zone    = powerdns_zone.forward.name
+  name    = "nopr.consistency.sysa.xyz."
+  type    = "A"
+  ttl     = 300
+  records = ["198.18.50.11"]
+}
+
+resource "powerdns_ptr_record" "web" {
+  ip_address   = "198.18.50.10"
+  hostname     = "web.consistency.sysa.xyz."
+  ttl          = 300
+  reverse_zone = powerdns_reverse_zone.reverse.name
+}
+
+resource "powerdns_ptr_record" "gone" {
+  ip_address   = "198.18.50.12"
+  hostname     = "gone.consistency.sysa.xyz."
+  ttl          = 300
+  reverse_zone = powerdns_reverse_zone.reverse.name
+}
+
+resource "powerdns_ptr_record" "stale" {
+  ip_address   = "198.18.50.13"
+  hostname     = "web.consistency.sysa.xyz."
+  ttl          = 300
+  reverse_zone = powerdns_reverse_zone.reverse.name
+}
+
+data "powerdns_reverse_consistency" "test" {
+  forward_zones = [powerdns_zone.forward.name]
+  reverse_zones = [powerdns_reverse_zone.reverse.name]
+
+  depends_on = [
+    powerdns_record.web,
+    powerdns_record.nopr,
+    powerdns_ptr_record.web,
+    powerdns_ptr_record.gone,
+    powerdns_ptr_record.stale,
+  ]
+}
+`
@@ -0,0 +1,160 @@
+package powerdns
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// searchObjectTypes are the object types the search-data endpoint accepts.
+var searchObjectTypes = []string{"all", "zone", "record", "comment"}
+
+// dataSourcePDNSSearch searches the zones, records and comments of the
+// server.
+func dataSourcePDNSSearch() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePDNSSearchRead,
+
+		Schema: map[string]*schema.Schema{
+			"query": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "The text to search for. * matches any number of characters and ? a single one.",
+			},
+			"max": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The maximum number of results.",
+			},
+			"object_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "all",
+				ValidateFunc: validation.StringInSlice(searchObjectTypes, false),
+				Description:  "The type of object to search for: all, zone, record or comment.",
+			},
+			"results": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The matching objects, sorted by object type, zone, name, type and content.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"object_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the object: zone, record or comment.",
+						},
+						"zone": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The zone the object belongs to. For zones, the name of the zone.",
+						},
+						"zone_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the zone the object belongs to.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the zone, record or comment.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The record type of a record or comment.",
+						},
+						"content": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The content of a record or comment.",
+						},
+						"ttl": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The TTL of a record.",
+						},
+						"disabled": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether a record is disabled.",
+						},
+					},
+				},
+			},
+			"truncated": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the search returned max results, so that more objects may match.",
+			},
+		},
+	}
+}
+
+func dataSourcePDNSSearchRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderClients)
+
+	query := d.Get("query").(string)
+	maxResults := d.Get("max").(int)
+	objectType := d.Get("object_type").(string)
+	ctx = tflog.SetField(ctx, "query", query)
+	tflog.Info(ctx, "Reading search data source", map[string]any{"max": maxResults, "object_type": objectType})
+
+	results, err := client.PDNS.SearchData(ctx, query, maxResults, objectType)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't search for %q: %w", query, err))
+	}
+	sortSearchResults(results)
+
+	flattened := make([]map[string]interface{}, 0, len(results))
+	for _, result := range results {
+		zone := result.Zone
+		if result.ObjectType == "zone" && zone == "" {
+			zone = result.Name
+		}
+		flattened = append(flattened, map[string]interface{}{
+			"object_type": result.ObjectType,
+			"zone":        zone,
+			"zone_id":     result.ZoneID,
+			"name":        result.Name,
+			"type":        result.Type,
+			"content":     result.Content,
+			"ttl":         result.TTL,
+			"disabled":    result.Disabled,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("search/%s/%s/%d", objectType, query, maxResults))
+	if err := d.Set("results", flattened); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting results: %w", err))
+	}
+	if err := d.Set("truncated", len(results) >= maxResults); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting truncated: %w", err))
+	}
+
+	tflog.Info(ctx, "Searched server", map[string]any{"results": len(results)})
+	return nil
+}
+
+// sortSearchResults sorts results by object type, zone, name, type and
+// content, so the order does not depend on the backend.
+func sortSearchResults(results []SearchResult) {
+	slices.SortStableFunc(results, func(a, b SearchResult) int {
+		return cmp.Or(
+			cmp.Compare(a.ObjectType, b.ObjectType),
+			cmp.Compare(a.Zone, b.Zone),
+			cmp.Compare(a.Name, b.Name),
+			cmp.Compare(a.Type, b.Type),
+			cmp.Compare(a.Content, b.Content),
+		)
+	})
+}
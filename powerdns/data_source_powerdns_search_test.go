@@ -0,0 +1,93 @@
+package powerdns
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortSearchResults(t *testing.T) {
+	results := []SearchResult{
+		{ObjectType: "record", Zone: "b.example.", Name: "www.b.example.", Type: "A", Content: "192.0.2.1"},
+		{ObjectType: "zone", Name: "a.example."},
+		{ObjectType: "record", Zone: "a.example.", Name: "www.a.example.", Type: "AAAA", Content: "2001:db8::1"},
+		{ObjectType: "record", Zone: "a.example.", Name: "www.a.example.", Type: "A", Content: "192.0.2.2"},
+		{ObjectType: "comment", Zone: "a.example.", Name: "www.a.example.", Type: "A", Content: "web server"},
+	}
+	sortSearchResults(results)
+
+	var order []string
+	for _, r := range results {
+		order = append(order, r.ObjectType+" "+r.Name+" "+r.Type+" "+r.Content)
+	}
+	assert.Equal(t, []string{
+		"comment www.a.example. A web server",
+		"record www.a.example. A 192.0.2.2",
+		"record www.a.example. AAAA 2001:db8::1",
+		"record www.b.example. A 192.0.2.1",
+		"zone a.example.  ",
+	}, order)
+}
+
+func TestAccDataSourcePDNSSearch(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourcePDNSSearchConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerdns_search.records", "results.#", "2"),
+					resource.TestCheckResourceAttr("data.powerdns_search.records", "results.0.object_type", "record"),
+					resource.TestCheckResourceAttr("data.powerdns_search.records", "results.0.zone", "search.sysa.xyz."),
+					resource.TestCheckResourceAttr("data.powerdns_search.records", "results.0.name", "db.search.sysa.xyz."),
+					resource.TestCheckResourceAttr("data.powerdns_search.records", "results.0.type", "A"),
+					resource.TestCheckResourceAttr("data.powerdns_search.records", "results.0.content", "198.18.60.40"),
+					resource.TestCheckResourceAttr("data.powerdns_search.records", "results.1.name", "legacy-db.search.sysa.xyz."),
+					resource.TestCheckResourceAttr("data.powerdns_search.records", "truncated", "false"),
+					resource.TestCheckResourceAttr("data.powerdns_search.zones", "results.#", "1"),
+					resource.TestCheckResourceAttr("data.powerdns_search.zones", "results.0.object_type", "zone"),
+					resource.TestCheckResourceAttr("data.powerdns_search.zones", "results.0.zone", "search.sysa.xyz."),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourcePDNSSearchConfig = `
+resource "powerdns_zone" "search" {
+	name = "search.sysa.xyz."
+	kind = "Native"
+}
+
+resource "powerdns_record" "db" {
+	zone    = powerdns_zone.search.name
+	name    = "db.search.sysa.xyz."
+	type    = "A"
+	ttl     = 300
+	records = ["198.18.60.40"]
+}
+
+resource "powerdns_record" "legacy_db" {
+	zone    = powerdns_zone.search.name
+	name    = "legacy-db.search.sysa.xyz."
+	type    = "A"
+	ttl     = 300
+	records = ["198.18.60.40"]
+}
+
+data "powerdns_search" "records" {
+	query       = "198.18.60.40"
+	object_type = "record"
+
+	depends_on = [powerdns_record.db, powerdns_record.legacy_db]
+}
+
+data "powerdns_search" "zones" {
+	query       = "search.sysa.xyz."
+	object_type = "zone"
+
+	depends_on = [powerdns_zone.search]
+}`
@@ -0,0 +1,112 @@
+package powerdns
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourcePDNSServer describes the server the provider is configured for.
+func dataSourcePDNSServer() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePDNSServerRead,
+
+		Schema: map[string]*schema.Schema{
+			"server_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the server.",
+			},
+			"daemon_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the daemon: authoritative or recursor.",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version of the server, such as 4.9.1.",
+			},
+			"major_version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The major version of the server, or 0 if version cannot be parsed.",
+			},
+			"minor_version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The minor version of the server, or 0 if version cannot be parsed.",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The API endpoint of the server.",
+			},
+			"config_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The API endpoint of the server configuration.",
+			},
+			"zones_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The API endpoint of the zones of the server.",
+			},
+		},
+	}
+}
+
+func dataSourcePDNSServerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderClients)
+
+	serverID := client.PDNS.serverID
+	ctx = tflog.SetField(ctx, "server_id", serverID)
+	tflog.Info(ctx, "Reading server data source")
+
+	server, err := client.PDNS.GetServer(ctx, serverID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't fetch server %s: %w", serverID, err))
+	}
+	major, minor := parseServerVersion(server.Version)
+
+	d.SetId(server.ID)
+	fields := map[string]interface{}{
+		"server_id":     server.ID,
+		"daemon_type":   server.DaemonType,
+		"version":       server.Version,
+		"major_version": major,
+		"minor_version": minor,
+		"url":           server.URL,
+		"config_url":    server.ConfigURL,
+		"zones_url":     server.ZonesURL,
+	}
+	for key, value := range fields {
+		if err := d.Set(key, value); err != nil {
+			return diag.FromErr(fmt.Errorf("error setting %s: %w", key, err))
+		}
+	}
+
+	tflog.Info(ctx, "Read server", map[string]any{"version": server.Version})
+	return nil
+}
+
+// parseServerVersion returns the major and minor version of a version such
+// as 4.9.1 or 5.0.0-alpha1. Parts that cannot be parsed are returned as 0.
+func parseServerVersion(version string) (int, int) {
+	parts := strings.SplitN(version, ".", 3)
+	numbers := make([]int, 2)
+	for i := 0; i < len(numbers) && i < len(parts); i++ {
+		digits := strings.TrimRightFunc(parts[i], func(r rune) bool { return r < '0' || r > '9' })
+		n, err := strconv.Atoi(digits)
+		if err != nil {
+			break
+		}
+		numbers[i] = n
+	}
+	return numbers[0], numbers[1]
+}
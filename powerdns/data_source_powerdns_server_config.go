@@ -0,0 +1,53 @@
+package powerdns
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourcePDNSServerConfig returns the running configuration of the server
+// the provider is configured for.
+func dataSourcePDNSServerConfig() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePDNSServerConfigRead,
+
+		Schema: map[string]*schema.Schema{
+			"config": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The running configuration, keyed by setting name. Settings with several values are joined with \", \".",
+			},
+		},
+	}
+}
+
+func dataSourcePDNSServerConfigRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderClients)
+
+	serverID := client.PDNS.serverID
+	ctx = tflog.SetField(ctx, "server_id", serverID)
+	tflog.Info(ctx, "Reading server config data source")
+
+	settings, err := client.PDNS.GetServerConfig(ctx, serverID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't fetch config of server %s: %w", serverID, err))
+	}
+
+	config := make(map[string]string, len(settings))
+	for _, setting := range settings {
+		config[setting.Name] = setting.Value
+	}
+
+	d.SetId(serverID)
+	if err := d.Set("config", config); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting config: %w", err))
+	}
+
+	tflog.Info(ctx, "Read server config", map[string]any{"settings": len(config)})
+	return nil
+}
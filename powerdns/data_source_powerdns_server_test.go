@@ -0,0 +1,92 @@
+package powerdns
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetServer(t *testing.T) {
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/api/v1/servers/localhost", r.URL.Path)
+		return jsonResponse(http.StatusOK, `{
+			"type": "Server", "id": "localhost", "daemon_type": "authoritative", "version": "4.9.1",
+			"url": "/api/v1/servers/localhost", "config_url": "/api/v1/servers/localhost/config{/config_setting}",
+			"zones_url": "/api/v1/servers/localhost/zones{/zone}"
+		}`), nil
+	})
+
+	server, err := client.GetServer(context.Background(), client.serverID)
+	assert.NoError(t, err)
+	assert.Equal(t, "localhost", server.ID)
+	assert.Equal(t, "authoritative", server.DaemonType)
+	assert.Equal(t, "4.9.1", server.Version)
+	assert.Equal(t, "/api/v1/servers/localhost", server.URL)
+}
+
+func TestGetServerConfig(t *testing.T) {
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/api/v1/servers/localhost/config", r.URL.Path)
+		return jsonResponse(http.StatusOK, `[
+			{"type": "ConfigSetting", "name": "default-soa-edit", "value": "INCEPTION-INCREMENT"},
+			{"type": "ConfigSetting", "name": "primary", "value": "yes"},
+			{"type": "ConfigSetting", "name": "allow-from", "value": ["127.0.0.0/8", "::1/128"]}
+		]`), nil
+	})
+
+	settings, err := client.GetServerConfig(context.Background(), client.serverID)
+	assert.NoError(t, err)
+	assert.Equal(t, []ServerConfigSetting{
+		{Name: "default-soa-edit", Value: "INCEPTION-INCREMENT"},
+		{Name: "primary", Value: "yes"},
+		{Name: "allow-from", Value: "127.0.0.0/8, ::1/128"},
+	}, settings)
+}
+
+func TestGetServerConfigError(t *testing.T) {
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusNotFound, `{"error": "Not Found"}`), nil
+	})
+
+	_, err := client.GetServerConfig(context.Background(), "missing")
+	assert.ErrorContains(t, err, `error getting config of server: missing, reason: "Not Found"`)
+}
+
+func TestParseServerVersion(t *testing.T) {
+	for version, want := range map[string][2]int{
+		"4.9.1":        {4, 9},
+		"5.0.0-alpha1": {5, 0},
+		"4.8":          {4, 8},
+		"5":            {5, 0},
+		"master":       {0, 0},
+		"":             {0, 0},
+	} {
+		major, minor := parseServerVersion(version)
+		assert.Equal(t, want, [2]int{major, minor}, version)
+	}
+}
+
+func TestAccDataSourcePDNSServer(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "powerdns_server" "test" {}
+
+data "powerdns_server_config" "test" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerdns_server.test", "server_id", "localhost"),
+					resource.TestCheckResourceAttr("data.powerdns_server.test", "daemon_type", "authoritative"),
+					resource.TestCheckResourceAttrSet("data.powerdns_server.test", "version"),
+					resource.TestCheckResourceAttrSet("data.powerdns_server.test", "major_version"),
+					resource.TestCheckResourceAttrSet("data.powerdns_server_config.test", "config.default-soa-edit"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,162 @@
+package powerdns
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// recursorServerID is the server ID of the recursor, which serves only
+// localhost.
+const recursorServerID = "localhost"
+
+// dataSourcePDNSStatistics returns the statistics of the authoritative server
+// or of the recursor.
+func dataSourcePDNSStatistics() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePDNSStatisticsRead,
+
+		Schema: map[string]*schema.Schema{
+			"statistic": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "The name of a single statistic to return. By default, all statistics are returned.",
+			},
+			"include_rings": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to return the ring statistics, such as the top queried names.",
+			},
+			"recursor": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to return the statistics of the recursor instead of the authoritative server. Requires recursor_server_url.",
+			},
+			"statistics": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The statistics, in the order returned by the server.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the statistic.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the statistic: StatisticItem, MapStatisticItem or RingStatisticItem.",
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The value of a StatisticItem.",
+						},
+						"size": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The size of the ring of a RingStatisticItem.",
+						},
+						"entries": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The entries of a MapStatisticItem or RingStatisticItem.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"value": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"values": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The values of the StatisticItem statistics, keyed by name.",
+			},
+		},
+	}
+}
+
+func dataSourcePDNSStatisticsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	statistic := d.Get("statistic").(string)
+	includeRings := d.Get("include_rings").(bool)
+
+	var client *BaseClient
+	var serverID string
+	if d.Get("recursor").(bool) {
+		recursorClient, diags := getRecursorClient(meta)
+		if diags != nil {
+			return diags
+		}
+		client, serverID = recursorClient.BaseClient, recursorServerID
+	} else {
+		pdnsClient := meta.(*ProviderClients).PDNS
+		client, serverID = pdnsClient.BaseClient, pdnsClient.serverID
+	}
+
+	ctx = tflog.SetField(ctx, "server_id", serverID)
+	tflog.Info(ctx, "Reading statistics data source", map[string]any{"statistic": statistic, "include_rings": includeRings})
+
+	statistics, err := client.GetStatistics(ctx, serverID, statistic, includeRings)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't fetch statistics of server %s: %w", serverID, err))
+	}
+
+	flattened := make([]map[string]interface{}, 0, len(statistics))
+	values := make(map[string]string)
+	for _, item := range statistics {
+		entries := make([]map[string]interface{}, 0, len(item.Entries))
+		for _, entry := range item.Entries {
+			entries = append(entries, map[string]interface{}{
+				"name":  entry.Name,
+				"value": entry.Value,
+			})
+		}
+		flattened = append(flattened, map[string]interface{}{
+			"name":    item.Name,
+			"type":    item.Type,
+			"value":   item.Value,
+			"size":    item.Size,
+			"entries": entries,
+		})
+		if item.Type == StatisticItemType {
+			values[item.Name] = item.Value
+		}
+	}
+
+	id := serverID + "/statistics"
+	if d.Get("recursor").(bool) {
+		id = "recursor/" + id
+	}
+	if statistic != "" {
+		id += "/" + statistic
+	}
+	d.SetId(id)
+	if err := d.Set("statistics", flattened); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting statistics: %w", err))
+	}
+	if err := d.Set("values", values); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting values: %w", err))
+	}
+
+	tflog.Info(ctx, "Read statistics", map[string]any{"statistics": len(statistics)})
+	return nil
+}
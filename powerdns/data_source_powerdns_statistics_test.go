@@ -0,0 +1,101 @@
+package powerdns
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+const testStatisticsResponse = `[
+	{"type": "StatisticItem", "name": "uptime", "value": "86400"},
+	{"type": "MapStatisticItem", "name": "response-by-qtype", "value": [{"name": "A", "value": "120"}, {"name": "AAAA", "value": "30"}]},
+	{"type": "RingStatisticItem", "name": "queries", "size": "10000", "value": [{"name": "www.example.com/A", "value": "42"}]}
+]`
+
+func TestGetStatistics(t *testing.T) {
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/api/v1/servers/localhost/statistics", r.URL.Path)
+		assert.Equal(t, "uptime", r.URL.Query().Get("statistic"))
+		assert.Equal(t, "false", r.URL.Query().Get("includerings"))
+		return jsonResponse(http.StatusOK, testStatisticsResponse), nil
+	})
+
+	statistics, err := client.GetStatistics(context.Background(), client.serverID, "uptime", false)
+	assert.NoError(t, err)
+	assert.Equal(t, []StatisticItem{
+		{Type: StatisticItemType, Name: "uptime", Value: "86400"},
+		{Type: MapStatisticItemType, Name: "response-by-qtype", Entries: []SimpleStatisticItem{
+			{Name: "A", Value: "120"},
+			{Name: "AAAA", Value: "30"},
+		}},
+		{Type: RingStatisticItemType, Name: "queries", Size: 10000, Entries: []SimpleStatisticItem{
+			{Name: "www.example.com/A", Value: "42"},
+		}},
+	}, statistics)
+}
+
+func TestGetStatisticsError(t *testing.T) {
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusUnprocessableEntity, `{"error": "Unknown statistic name"}`), nil
+	})
+
+	_, err := client.GetStatistics(context.Background(), client.serverID, "missing", true)
+	assert.ErrorContains(t, err, `reason: "Unknown statistic name"`)
+}
+
+func TestDataSourcePDNSStatisticsReadRecursor(t *testing.T) {
+	recursor := newTestClient(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/api/v1/servers/localhost/statistics", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("includerings"))
+		return jsonResponse(http.StatusOK, testStatisticsResponse), nil
+	})
+	meta := &ProviderClients{Recursor: &RecursorClient{BaseClient: recursor.BaseClient}}
+
+	d := schema.TestResourceDataRaw(t, dataSourcePDNSStatistics().Schema, map[string]interface{}{
+		"recursor": true,
+	})
+	assert.False(t, dataSourcePDNSStatisticsRead(context.Background(), d, meta).HasError())
+	assert.Equal(t, "recursor/localhost/statistics", d.Id())
+	assert.Equal(t, map[string]interface{}{"uptime": "86400"}, d.Get("values"))
+	assert.Equal(t, 3, d.Get("statistics.#"))
+	assert.Equal(t, 10000, d.Get("statistics.2.size"))
+	assert.Equal(t, "AAAA", d.Get("statistics.1.entries.1.name"))
+}
+
+func TestDataSourcePDNSStatisticsReadRecursorNotConfigured(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSourcePDNSStatistics().Schema, map[string]interface{}{
+		"recursor": true,
+	})
+	diags := dataSourcePDNSStatisticsRead(context.Background(), d, &ProviderClients{})
+	assert.True(t, diags.HasError())
+	assert.Equal(t, "Recursor client not configured", diags[0].Summary)
+}
+
+func TestAccDataSourcePDNSStatistics(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "powerdns_statistics" "all" {
+	include_rings = false
+}
+
+data "powerdns_statistics" "uptime" {
+	statistic = "uptime"
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.powerdns_statistics.all", "values.uptime"),
+					resource.TestCheckResourceAttr("data.powerdns_statistics.uptime", "statistics.#", "1"),
+					resource.TestCheckResourceAttr("data.powerdns_statistics.uptime", "statistics.0.name", "uptime"),
+					resource.TestCheckResourceAttr("data.powerdns_statistics.uptime", "statistics.0.type", "StatisticItem"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,135 @@
+package powerdns
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourcePDNSZoneExport returns the contents of a zone as BIND zone file
+// text, and optionally as RRsets.
+func dataSourcePDNSZoneExport() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePDNSZoneExportRead,
+
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: ValidateZoneName,
+				Description:  "The name of the zone to export.",
+			},
+			"structured": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to also return the zone as RRsets. When the export endpoint is unavailable, the RRsets are read from the zone instead and content is rendered from them.",
+			},
+			"content": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The zone in BIND zone file format.",
+			},
+			"rrsets": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The RRsets of the zone, sorted by name and type. Only set when structured is true.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The fully qualified name of the RRset.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The record type of the RRset.",
+						},
+						"ttl": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The TTL of the RRset.",
+						},
+						"records": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The contents of the records in the RRset.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePDNSZoneExportRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderClients)
+
+	zone := d.Get("zone").(string)
+	structured := d.Get("structured").(bool)
+	ctx = tflog.SetField(ctx, "zone", zone)
+	tflog.Info(ctx, "Reading zone export data source")
+
+	var rrSets []ResourceRecordSet
+	content, err := client.PDNS.ExportZone(ctx, zone)
+	switch {
+	case err == nil && structured:
+		if rrSets, err = rrSetsFromZoneFile(content, zone); err != nil {
+			return diag.FromErr(fmt.Errorf("couldn't parse export of zone %s: %w", zone, err))
+		}
+	case err != nil && structured:
+		tflog.Warn(ctx, "Zone export unavailable; reading RRsets instead", map[string]any{"error": err.Error()})
+		zoneInfo, err := client.PDNS.GetZoneWithRRsets(ctx, zone)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("couldn't fetch zone %s: %w", zone, err))
+		}
+		rrSets = enabledRRSets(zoneInfo.ResourceRecordSets)
+		sortRRSets(rrSets)
+		content = zoneFileFromRRSets(rrSets)
+	case err != nil:
+		return diag.FromErr(fmt.Errorf("couldn't export zone %s: %w", zone, err))
+	}
+
+	flattened := make([]map[string]interface{}, 0, len(rrSets))
+	for _, rrSet := range rrSets {
+		records := make([]string, 0, len(rrSet.Records))
+		for _, record := range rrSet.Records {
+			records = append(records, record.Content)
+		}
+		flattened = append(flattened, map[string]interface{}{
+			"name":    rrSet.Name,
+			"type":    rrSet.Type,
+			"ttl":     rrSet.TTL,
+			"records": records,
+		})
+	}
+
+	d.SetId(zone)
+	if err := d.Set("content", content); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting content: %w", err))
+	}
+	if err := d.Set("rrsets", flattened); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting rrsets: %w", err))
+	}
+
+	tflog.Info(ctx, "Exported zone", map[string]any{"bytes": len(content), "rrset_count": len(rrSets)})
+	return nil
+}
+
+// enabledRRSets drops the disabled records from rrSets, and the RRsets that
+// are left without records.
+func enabledRRSets(rrSets []ResourceRecordSet) []ResourceRecordSet {
+	enabled := make([]ResourceRecordSet, 0, len(rrSets))
+	for _, rrSet := range rrSets {
+		rrSet.Records = enabledRecords(rrSet.Records)
+		if len(rrSet.Records) > 0 {
+			enabled = append(enabled, rrSet)
+		}
+	}
+	return enabled
+}
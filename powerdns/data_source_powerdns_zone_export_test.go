@@ -0,0 +1,60 @@
+package powerdns
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourcePDNSZoneExport(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourcePDNSZoneExportConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.powerdns_zone_export.text", "content",
+						regexp.MustCompile(`(?m)^www\.export\.sysa\.xyz\.\s+300\s+IN\s+A\s+192\.0\.2\.10$`)),
+					resource.TestCheckResourceAttr("data.powerdns_zone_export.text", "rrsets.#", "0"),
+					resource.TestCheckResourceAttrPair("data.powerdns_zone_export.structured", "content",
+						"data.powerdns_zone_export.text", "content"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.powerdns_zone_export.structured", "rrsets.*", map[string]string{
+						"name":      "www.export.sysa.xyz.",
+						"type":      "A",
+						"ttl":       "300",
+						"records.#": "2",
+					}),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourcePDNSZoneExportConfig = `
+resource "powerdns_zone" "test" {
+  name = "export.sysa.xyz."
+  kind = "Native"
+}
+
+resource "powerdns_record" "www" {
+  zone    = powerdns_zone.test.name
+  name    = "www.export.sysa.xyz."
+  type    = "A"
+  ttl     = 300
+  records = ["192.0.2.10", "192.0.2.11"]
+}
+
+data "powerdns_zone_export" "text" {
+  zone       = powerdns_zone.test.name
+  depends_on = [powerdns_record.www]
+}
+
+data "powerdns_zone_export" "structured" {
+  zone       = powerdns_zone.test.name
+  structured = true
+  depends_on = [powerdns_record.www]
+}
+`
@@ -0,0 +1,204 @@
+package powerdns
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// dataSourcePDNSZones lists the zones on the server, optionally filtered.
+func dataSourcePDNSZones() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePDNSZonesRead,
+
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				Description:  "A regular expression the zone name must match.",
+			},
+			"kind": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return zones of this kind. The comparison ignores case.",
+			},
+			"account": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return zones owned by this account.",
+			},
+			"catalog": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: ValidateZoneName,
+				Description:  "Only return members of this catalog zone.",
+			},
+			"dnssec": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only return zones that are, or are not, signed with DNSSEC.",
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The names of the matching zones, sorted.",
+			},
+			"zones": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The matching zones, sorted by name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the zone.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the zone.",
+						},
+						"kind": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The kind of the zone.",
+						},
+						"serial": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The SOA serial of the zone.",
+						},
+						"account": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The account that owns the zone.",
+						},
+						"catalog": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The catalog zone the zone is a member of.",
+						},
+						"dnssec": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the zone is signed with DNSSEC.",
+						},
+						"masters": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The primaries of a Slave zone.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// zoneFilter selects zones by their attributes. Empty fields and a nil
+// dnssec match every zone.
+type zoneFilter struct {
+	nameRegex *regexp.Regexp
+	kind      string
+	account   string
+	catalog   string
+	dnssec    *bool
+}
+
+func (f zoneFilter) matches(zone ZoneInfo) bool {
+	switch {
+	case f.nameRegex != nil && !f.nameRegex.MatchString(zone.Name):
+		return false
+	case f.kind != "" && !strings.EqualFold(f.kind, zone.Kind):
+		return false
+	case f.account != "" && f.account != zone.Account:
+		return false
+	case f.catalog != "" && !strings.EqualFold(f.catalog, zone.Catalog):
+		return false
+	case f.dnssec != nil && *f.dnssec != zone.DNSSec:
+		return false
+	}
+	return true
+}
+
+// filterZones returns the zones that match filter, sorted by name.
+func filterZones(zones []ZoneInfo, filter zoneFilter) []ZoneInfo {
+	var matches []ZoneInfo
+	for _, zone := range zones {
+		if filter.matches(zone) {
+			matches = append(matches, zone)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].Name < matches[j].Name
+	})
+	return matches
+}
+
+func dataSourcePDNSZonesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderClients)
+
+	filter := zoneFilter{
+		kind:    d.Get("kind").(string),
+		account: d.Get("account").(string),
+		catalog: d.Get("catalog").(string),
+	}
+	if nameRegex := d.Get("name_regex").(string); nameRegex != "" {
+		var err error
+		if filter.nameRegex, err = regexp.Compile(nameRegex); err != nil {
+			return diag.FromErr(fmt.Errorf("invalid name_regex: %w", err))
+		}
+	}
+	// A bool that is not configured reads as false, so the raw configuration
+	// tells "dnssec = false" apart from no filter at all.
+	if rawConfig := d.GetRawConfig(); !rawConfig.IsNull() && rawConfig.IsKnown() && !rawConfig.GetAttr("dnssec").IsNull() {
+		dnssec := d.Get("dnssec").(bool)
+		filter.dnssec = &dnssec
+	}
+
+	tflog.Info(ctx, "Reading zones data source")
+
+	zones, err := client.PDNS.ListZones(ctx)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't list zones: %w", err))
+	}
+	matches := filterZones(zones, filter)
+
+	names := make([]string, 0, len(matches))
+	flattened := make([]map[string]interface{}, 0, len(matches))
+	for _, zone := range matches {
+		names = append(names, zone.Name)
+		flattened = append(flattened, map[string]interface{}{
+			"id":      zone.ID,
+			"name":    zone.Name,
+			"kind":    zone.Kind,
+			"serial":  int(zone.Serial),
+			"account": zone.Account,
+			"catalog": zone.Catalog,
+			"dnssec":  zone.DNSSec,
+			"masters": zone.Masters,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("zones/%s/%s/%s/%s/%v", d.Get("name_regex"), filter.kind, filter.account, filter.catalog, d.Get("dnssec")))
+	if err := d.Set("names", names); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting names: %w", err))
+	}
+	if err := d.Set("zones", flattened); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting zones: %w", err))
+	}
+
+	tflog.Info(ctx, "Listed zones", map[string]any{"total": len(zones), "matching": len(matches)})
+	return nil
+}
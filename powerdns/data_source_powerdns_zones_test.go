@@ -0,0 +1,90 @@
+package powerdns
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterZones(t *testing.T) {
+	zones := []ZoneInfo{
+		{Name: "b.example.com.", Kind: "Native", Account: "ops", DNSSec: true},
+		{Name: "a.example.com.", Kind: "Slave", Account: "ops", Catalog: "catalog.example."},
+		{Name: "example.net.", Kind: "Master", Account: "web", Catalog: "catalog.example."},
+		{Name: "2.0.192.in-addr.arpa.", Kind: "Native", Account: "ops"},
+	}
+	dnssecOn, dnssecOff := true, false
+
+	names := func(filter zoneFilter) []string {
+		var result []string
+		for _, zone := range filterZones(zones, filter) {
+			result = append(result, zone.Name)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"2.0.192.in-addr.arpa.", "a.example.com.", "b.example.com.", "example.net."}, names(zoneFilter{}))
+	assert.Equal(t, []string{"a.example.com.", "b.example.com."}, names(zoneFilter{nameRegex: regexp.MustCompile(`\.example\.com\.$`)}))
+	assert.Equal(t, []string{"a.example.com."}, names(zoneFilter{kind: "slave", catalog: "catalog.example."}))
+	assert.Equal(t, []string{"example.net."}, names(zoneFilter{account: "web"}))
+	assert.Equal(t, []string{"b.example.com."}, names(zoneFilter{dnssec: &dnssecOn}))
+	assert.Equal(t, []string{"2.0.192.in-addr.arpa.", "a.example.com."}, names(zoneFilter{account: "ops", dnssec: &dnssecOff}))
+	assert.Empty(t, names(zoneFilter{kind: "Producer"}))
+}
+
+func TestAccDataSourcePDNSZones(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourcePDNSZonesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerdns_zones.account", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.powerdns_zones.account", "names.0", "one.zones.sysa.xyz."),
+					resource.TestCheckResourceAttr("data.powerdns_zones.account", "zones.1.name", "two.zones.sysa.xyz."),
+					resource.TestCheckResourceAttr("data.powerdns_zones.account", "zones.1.kind", "Master"),
+					resource.TestCheckResourceAttr("data.powerdns_zones.account", "zones.1.account", "zones-test"),
+					resource.TestCheckResourceAttrSet("data.powerdns_zones.account", "zones.1.serial"),
+					resource.TestCheckResourceAttr("data.powerdns_zones.native", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.powerdns_zones.native", "names.0", "one.zones.sysa.xyz."),
+					resource.TestCheckResourceAttr("data.powerdns_zones.unsigned", "names.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourcePDNSZonesConfig = `
+resource "powerdns_zone" "one" {
+  name    = "one.zones.sysa.xyz."
+  kind    = "Native"
+  account = "zones-test"
+}
+
+resource "powerdns_zone" "two" {
+  name    = "two.zones.sysa.xyz."
+  kind    = "Master"
+  account = "zones-test"
+}
+
+data "powerdns_zones" "account" {
+  account    = "zones-test"
+  depends_on = [powerdns_zone.one, powerdns_zone.two]
+}
+
+data "powerdns_zones" "native" {
+  name_regex = "\\.zones\\.sysa\\.xyz\\.$"
+  kind       = "native"
+  depends_on = [powerdns_zone.one, powerdns_zone.two]
+}
+
+data "powerdns_zones" "unsigned" {
+  account    = "zones-test"
+  dnssec     = false
+  depends_on = [powerdns_zone.one, powerdns_zone.two]
+}
+`
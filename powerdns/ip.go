@@ -2,6 +2,7 @@ package powerdns
 
 import (
 	"fmt"
+	"math/big"
 	"net"
 	"strconv"
 	"strings"
@@ -38,6 +39,28 @@ func ValidateFQDN(v interface{}, k string) (ws []string, errors []error) {
 	return
 }
 
+// ValidateRecordName validates a record name that is either a fully qualified
+// domain name with a trailing dot, "@" for the zone apex, or a name relative to
+// the zone such as "www" or "_sip._tcp".
+func ValidateRecordName(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value == "" {
+		errors = append(errors, fmt.Errorf("%q must be non-empty", k))
+		return
+	}
+	if value == "." {
+		errors = append(errors, fmt.Errorf("%q must not be the root name \".\"", k))
+		return
+	}
+	if value == "@" {
+		return
+	}
+	if strings.HasPrefix(value, ".") || strings.Contains(value, "..") {
+		errors = append(errors, fmt.Errorf("%q must not contain empty labels, got: %s", k, value))
+	}
+	return
+}
+
 // ValidateZoneName validates a PowerDNS zone name, including PowerDNS view variants.
 //
 // Accepted forms:
@@ -101,9 +124,12 @@ func ValidateZoneName(v interface{}, k string) (ws []string, errors []error) {
 	return
 }
 
-// ValidateCIDR validates the CIDR format.
-// For IPv4, only /8, /16, /24 are allowed.
+// ValidateCIDR validates the CIDR of a single reverse zone.
+// For IPv4, the prefix length must be 8, 16 or 24, or between 25 and 32 for
+// an RFC 2317 classless zone.
 // For IPv6, prefix length must be a multiple of 4 between 4 and 124.
+// Other blocks span several zones, and the errors point to
+// powerdns_reverse_zones.
 func ValidateCIDR(v interface{}, k string) (ws []string, errors []error) {
 	cidr := v.(string)
 	ip, ipnet, err := net.ParseCIDR(cidr)
@@ -119,15 +145,16 @@ func ValidateCIDR(v interface{}, k string) (ws []string, errors []error) {
 
 	// Check if it's an IPv4 or IPv6 CIDR
 	if ipnet.IP.To4() != nil {
-		// IPv4 CIDR
-		if ones != 8 && ones != 16 && ones != 24 {
-			errors = append(errors, fmt.Errorf("IPv4 prefix length must be 8, 16, or 24"))
+		// IPv4 CIDR: octet-aligned prefixes map to a zone directly, longer
+		// ones get an RFC 2317 classless zone inside their /24.
+		if ones != 8 && ones != 16 && ones < 24 {
+			errors = append(errors, fmt.Errorf("IPv4 prefix length must be 8, 16, or 24, or between 25 and 32 for RFC 2317 classless zones, got /%d; use powerdns_reverse_zones for a block that spans several reverse zones", ones))
 			return
 		}
 	} else {
 		// IPv6 CIDR
 		if ones%4 != 0 || ones < 4 || ones > 124 {
-			errors = append(errors, fmt.Errorf("IPv6 prefix length must be a multiple of 4 between 4 and 124"))
+			errors = append(errors, fmt.Errorf("IPv6 prefix length must be a multiple of 4 between 4 and 124, got /%d; use powerdns_reverse_zones for a block that spans several reverse zones", ones))
 			return
 		}
 	}
@@ -135,11 +162,89 @@ func ValidateCIDR(v interface{}, k string) (ws []string, errors []error) {
 	return
 }
 
+// ValidateClasslessCIDR validates an IPv4 prefix between /25 and /32, the
+// blocks that are delegated with RFC 2317 classless reverse zones.
+func ValidateClasslessCIDR(v interface{}, k string) (ws []string, errors []error) {
+	_, ipnet, err := net.ParseCIDR(v.(string))
+	if err != nil {
+		errors = append(errors, fmt.Errorf("invalid CIDR format: %s", err))
+		return
+	}
+
+	ones, _ := ipnet.Mask.Size()
+	if ipnet.IP.To4() == nil || ones <= 24 {
+		errors = append(errors, fmt.Errorf("%q must be an IPv4 prefix between /25 and /32, got: %s", k, v.(string)))
+	}
+
+	return
+}
+
+// SplitReverseZoneCIDR returns the smallest set of blocks that each map to a
+// single reverse zone and together cover cidr. IPv4 prefixes are rounded up
+// to the next octet boundary and IPv6 prefixes to the next nibble boundary,
+// so a /46 becomes four /48 blocks. IPv4 prefixes longer than /24 are kept
+// as they are, for an RFC 2317 classless zone.
+func SplitReverseZoneCIDR(cidr string) ([]string, error) {
+	_, ipnet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIDR: %s", err)
+	}
+
+	ones, bits := ipnet.Mask.Size()
+	ip := ipnet.IP.To4()
+	aligned := ones
+	if ip != nil {
+		if ones < 8 {
+			return nil, fmt.Errorf("IPv4 prefix length must be at least 8, got /%d", ones)
+		}
+		if ones < 24 {
+			aligned = (ones + 7) / 8 * 8
+		}
+	} else {
+		ip = ipnet.IP.To16()
+		if ones < 1 || ones > 124 {
+			return nil, fmt.Errorf("IPv6 prefix length must be between 1 and 124, got /%d", ones)
+		}
+		aligned = (ones + 3) / 4 * 4
+	}
+
+	base := new(big.Int).SetBytes(ip)
+	step := new(big.Int).Lsh(big.NewInt(1), uint(bits-aligned))
+	blocks := make([]string, 0, 1<<(aligned-ones))
+	for i := 0; i < 1<<(aligned-ones); i++ {
+		block := make(net.IP, len(ip))
+		base.FillBytes(block)
+		blocks = append(blocks, fmt.Sprintf("%s/%d", block, aligned))
+		base.Add(base, step)
+	}
+	return blocks, nil
+}
+
+// ValidateReverseZonesCIDR validates a block that SplitReverseZoneCIDR can
+// split into reverse zones.
+func ValidateReverseZonesCIDR(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := SplitReverseZoneCIDR(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q: %s", k, err))
+	}
+	return
+}
+
 // ParsePTRRecordName converts a PTR record name back to an IP address.
+// Names inside an RFC 2317 classless zone, such as
+// "10.0/26.2.0.192.in-addr.arpa.", are understood as well.
 func ParsePTRRecordName(name string) (net.IP, error) {
 	if strings.HasSuffix(name, ".in-addr.arpa.") {
 		// IPv4 PTR record
 		parts := strings.Split(strings.TrimSuffix(name, ".in-addr.arpa."), ".")
+		var block *net.IPNet
+		if len(parts) == 5 && strings.Contains(parts[1], "/") {
+			// Drop the classless label; the remaining labels are the address.
+			var err error
+			if block, err = parseClasslessLabel(parts[1], parts[2:]); err != nil {
+				return nil, fmt.Errorf("invalid classless PTR record name %s: %w", name, err)
+			}
+			parts = append(parts[:1], parts[2:]...)
+		}
 		if len(parts) != 4 {
 			return nil, fmt.Errorf("invalid IPv4 PTR record name format: %s", name)
 		}
@@ -151,6 +256,9 @@ func ParsePTRRecordName(name string) (net.IP, error) {
 		if ip == nil {
 			return nil, fmt.Errorf("invalid IPv4 address in PTR record name: %s", name)
 		}
+		if block != nil && !block.Contains(ip) {
+			return nil, fmt.Errorf("address %s is outside the classless block %s in PTR record name: %s", ip, block, name)
+		}
 		return ip, nil
 	} else if strings.HasSuffix(name, ".ip6.arpa.") {
 		// IPv6 PTR record
@@ -212,12 +320,86 @@ func GetPTRRecordName(ip string) (string, error) {
 	return strings.Join(ptrParts, "."), nil
 }
 
+// GetPTRRecordFQDN returns the fully qualified PTR record name of ip inside
+// the given reverse zone. In an RFC 2317 classless zone the record sits
+// directly below the zone ("10.0/26.2.0.192.in-addr.arpa."); in any other
+// zone it is the regular reverse name.
+func GetPTRRecordFQDN(ip string, zone string) (string, error) {
+	ptrName, err := GetPTRRecordName(ip)
+	if err != nil {
+		return "", err
+	}
+
+	parsedIP := net.ParseIP(ip)
+	if parsedIP.To4() == nil {
+		return ptrName + ".ip6.arpa.", nil
+	}
+
+	if !IsClasslessReverseZone(zone) {
+		return ptrName + ".in-addr.arpa.", nil
+	}
+
+	cidr, err := ParseReverseZoneName(zone)
+	if err != nil {
+		return "", err
+	}
+	_, block, _ := net.ParseCIDR(cidr)
+	if !block.Contains(parsedIP) {
+		return "", fmt.Errorf("IP address %s is outside the classless reverse zone %s (%s)", ip, zone, cidr)
+	}
+
+	return fmt.Sprintf("%d.%s", parsedIP.To4()[3], zone), nil
+}
+
+// IsClasslessReverseZone reports whether a zone name follows the RFC 2317
+// layout "<first-address>/<prefix>.<c>.<b>.<a>.in-addr.arpa.".
+func IsClasslessReverseZone(zone string) bool {
+	labels := strings.Split(strings.TrimSuffix(zone, ".in-addr.arpa."), ".")
+	return strings.HasSuffix(zone, ".in-addr.arpa.") && len(labels) == 4 && strings.Contains(labels[0], "/")
+}
+
+// parseClasslessLabel parses the "<first-address>/<prefix>" label of an
+// RFC 2317 zone together with the reversed labels of its parent /24.
+func parseClasslessLabel(label string, parent []string) (*net.IPNet, error) {
+	if len(parent) != 3 {
+		return nil, fmt.Errorf("classless label %s must sit directly below a /24 reverse zone", label)
+	}
+
+	start, prefix, _ := strings.Cut(label, "/")
+	ones, err := strconv.Atoi(prefix)
+	if err != nil || ones <= 24 || ones > 32 {
+		return nil, fmt.Errorf("invalid classless prefix length in label %s, must be between 25 and 32", label)
+	}
+
+	ip := net.ParseIP(fmt.Sprintf("%s.%s.%s.%s", parent[2], parent[1], parent[0], start))
+	if ip == nil {
+		return nil, fmt.Errorf("invalid address in classless label %s", label)
+	}
+
+	block := &net.IPNet{IP: ip.To4(), Mask: net.CIDRMask(ones, 32)}
+	if !ip.To4().Equal(ip.Mask(block.Mask)) {
+		return nil, fmt.Errorf("classless label %s does not start on a /%d boundary", label, ones)
+	}
+
+	return block, nil
+}
+
 // ParseReverseZoneName converts a reverse zone FQDN to its corresponding CIDR.
 // Examples:
 //
-//	"16.172.in-addr.arpa."   -> "172.16.0.0/16"
-//	"b.a.9.8.ip6.arpa." (etc)-> "<ipv6-prefix>/8" (nibble * 4)
+//	"16.172.in-addr.arpa."       -> "172.16.0.0/16"
+//	"0/26.2.0.192.in-addr.arpa." -> "192.0.2.0/26" (RFC 2317)
+//	"b.a.9.8.ip6.arpa." (etc)    -> "<ipv6-prefix>/8" (nibble * 4)
 func ParseReverseZoneName(name string) (string, error) {
+	if IsClasslessReverseZone(name) {
+		labels := strings.Split(strings.TrimSuffix(name, ".in-addr.arpa."), ".")
+		block, err := parseClasslessLabel(labels[0], labels[1:])
+		if err != nil {
+			return "", fmt.Errorf("invalid classless reverse zone name %s: %w", name, err)
+		}
+		return block.String(), nil
+	}
+
 	if strings.HasSuffix(name, ".in-addr.arpa.") {
 		// IPv4 reverse zone
 		parts := strings.Split(strings.TrimSuffix(name, ".in-addr.arpa."), ".")
@@ -281,9 +463,10 @@ func ParseReverseZoneName(name string) (string, error) {
 // GetReverseZoneName computes the reverse zone FQDN from a CIDR.
 // Examples:
 
-// 10.0.0.0/8    -> 10.in-addr.arpa.
-// 172.16.0.0/16 -> 16.172.in-addr.arpa.
-// 2000::/4      -> 2.ip6.arpa.
+// 10.0.0.0/8      -> 10.in-addr.arpa.
+// 172.16.0.0/16   -> 16.172.in-addr.arpa.
+// 192.0.2.64/26   -> 64/26.2.0.192.in-addr.arpa. (RFC 2317)
+// 2000::/4        -> 2.ip6.arpa.
 func GetReverseZoneName(cidr string) (string, error) {
 	_, ipnet, err := net.ParseCIDR(cidr)
 	if err != nil {
@@ -294,6 +477,12 @@ func GetReverseZoneName(cidr string) (string, error) {
 	if ipnet.IP.To4() != nil {
 		// IPv4 reverse zone
 		ip := ipnet.IP.To4()
+
+		// Blocks smaller than a /24 get an RFC 2317 classless zone below
+		// their /24, named after the first address and the prefix length.
+		if ones > 24 {
+			return fmt.Sprintf("%d/%d.%d.%d.%d.in-addr.arpa.", ip[3], ones, ip[2], ip[1], ip[0]), nil
+		}
 		octets := ones / 8
 
 		// For /24 networks, we need to include the third octet in the zone name
@@ -1,6 +1,10 @@
 package powerdns
 
-import "testing"
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
 
 func TestValidateViewName(t *testing.T) {
 	tests := []struct {
@@ -126,6 +130,22 @@ func TestValidateFQDN(t *testing.T) {
 	}
 }
 
+func TestValidateRecordName(t *testing.T) {
+	valid := []string{"www.example.com.", "@", "www", "_sip._tcp"}
+	for _, value := range valid {
+		if _, errs := ValidateRecordName(value, "name"); len(errs) > 0 {
+			t.Errorf("ValidateRecordName(%q) unexpected error: %v", value, errs)
+		}
+	}
+
+	invalid := []string{"", ".", ".www", "www..example.com."}
+	for _, value := range invalid {
+		if _, errs := ValidateRecordName(value, "name"); len(errs) == 0 {
+			t.Errorf("ValidateRecordName(%q) expected error but got none", value)
+		}
+	}
+}
+
 func TestValidateMasterAddress(t *testing.T) {
 	valid := []string{
 		"192.0.2.1",
@@ -232,11 +252,26 @@ func TestValidateCIDR(t *testing.T) {
 			cidr:        "192.168.1.0/24",
 			expectError: false,
 		},
+		{
+			name:        "Valid IPv4 /26 classless CIDR",
+			cidr:        "192.0.2.64/26",
+			expectError: false,
+		},
+		{
+			name:        "Valid IPv4 /32 classless CIDR",
+			cidr:        "192.0.2.7/32",
+			expectError: false,
+		},
 		{
 			name:        "Invalid IPv4 CIDR - wrong prefix length",
 			cidr:        "10.0.0.0/12",
 			expectError: true,
 		},
+		{
+			name:        "Invalid IPv4 CIDR - prefix length too small",
+			cidr:        "10.0.0.0/7",
+			expectError: true,
+		},
 		{
 			name:        "Invalid IPv4 CIDR - invalid IP",
 			cidr:        "256.0.0.0/8",
@@ -317,6 +352,15 @@ func TestValidateCIDR(t *testing.T) {
 	}
 }
 
+func TestValidateCIDRPointsToReverseZones(t *testing.T) {
+	for _, cidr := range []string{"10.0.0.0/12", "172.16.0.0/20", "2001:db8::/46"} {
+		_, errs := ValidateCIDR(cidr, "cidr")
+		if len(errs) != 1 || !strings.Contains(errs[0].Error(), "use powerdns_reverse_zones") {
+			t.Errorf("ValidateCIDR(%q) expected an error pointing to powerdns_reverse_zones, got: %v", cidr, errs)
+		}
+	}
+}
+
 func TestGetPTRRecordName(t *testing.T) {
 	testCases := []struct {
 		name        string
@@ -428,6 +472,24 @@ func TestParsePTRRecordName(t *testing.T) {
 			expectedIP:  "",
 			expectError: true,
 		},
+		{
+			name:        "Valid IPv4 PTR record in classless zone",
+			ptrName:     "70.64/26.2.0.192.in-addr.arpa.",
+			expectedIP:  "192.0.2.70",
+			expectError: false,
+		},
+		{
+			name:        "Invalid IPv4 PTR record - outside classless block",
+			ptrName:     "10.64/26.2.0.192.in-addr.arpa.",
+			expectedIP:  "",
+			expectError: true,
+		},
+		{
+			name:        "Invalid IPv4 PTR record - bad classless label",
+			ptrName:     "70.64/20.2.0.192.in-addr.arpa.",
+			expectedIP:  "",
+			expectError: true,
+		},
 
 		// IPv6 test cases
 		{
@@ -533,6 +595,30 @@ func TestParseReverseZoneName(t *testing.T) {
 			expectedCIDR: "",
 			expectError:  true,
 		},
+		{
+			name:         "Valid IPv4 classless /26 zone",
+			zoneName:     "64/26.2.0.192.in-addr.arpa.",
+			expectedCIDR: "192.0.2.64/26",
+			expectError:  false,
+		},
+		{
+			name:         "Valid IPv4 classless /32 zone",
+			zoneName:     "7/32.2.0.192.in-addr.arpa.",
+			expectedCIDR: "192.0.2.7/32",
+			expectError:  false,
+		},
+		{
+			name:         "Invalid IPv4 classless zone - unaligned start",
+			zoneName:     "10/26.2.0.192.in-addr.arpa.",
+			expectedCIDR: "",
+			expectError:  true,
+		},
+		{
+			name:         "Invalid IPv4 classless zone - prefix length too small",
+			zoneName:     "0/24.2.0.192.in-addr.arpa.",
+			expectedCIDR: "",
+			expectError:  true,
+		},
 		{
 			name:         "Invalid IPv4 zone - invalid octet",
 			zoneName:     "256.in-addr.arpa.",
@@ -656,6 +742,18 @@ func TestGetReverseZoneName(t *testing.T) {
 			expectedZone: "10.in-addr.arpa.",
 			expectError:  false,
 		},
+		{
+			name:         "Valid IPv4 /26 classless CIDR",
+			cidr:         "192.0.2.64/26",
+			expectedZone: "64/26.2.0.192.in-addr.arpa.",
+			expectError:  false,
+		},
+		{
+			name:         "Valid IPv4 /27 classless CIDR with host bits",
+			cidr:         "192.0.2.70/27",
+			expectedZone: "64/27.2.0.192.in-addr.arpa.",
+			expectError:  false,
+		},
 
 		// IPv6 test cases
 		{
@@ -714,3 +812,112 @@ func TestGetReverseZoneName(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPTRRecordFQDN(t *testing.T) {
+	tests := []struct {
+		name        string
+		ip          string
+		zone        string
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "IPv4 in octet-aligned zone",
+			ip:       "192.0.2.70",
+			zone:     "2.0.192.in-addr.arpa.",
+			expected: "70.2.0.192.in-addr.arpa.",
+		},
+		{
+			name:     "IPv4 in classless zone",
+			ip:       "192.0.2.70",
+			zone:     "64/26.2.0.192.in-addr.arpa.",
+			expected: "70.64/26.2.0.192.in-addr.arpa.",
+		},
+		{
+			name:        "IPv4 outside classless zone",
+			ip:          "192.0.2.10",
+			zone:        "64/26.2.0.192.in-addr.arpa.",
+			expectError: true,
+		},
+		{
+			name:     "IPv6",
+			ip:       "2001:db8::1",
+			zone:     "8.b.d.0.1.0.0.2.ip6.arpa.",
+			expected: "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.",
+		},
+		{
+			name:        "Invalid IP",
+			ip:          "not-an-ip",
+			zone:        "2.0.192.in-addr.arpa.",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, err := GetPTRRecordFQDN(tt.ip, tt.zone)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("GetPTRRecordFQDN() expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("GetPTRRecordFQDN() unexpected error: %v", err)
+			}
+			if name != tt.expected {
+				t.Errorf("GetPTRRecordFQDN() = %v, want %v", name, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateClasslessCIDR(t *testing.T) {
+	for _, cidr := range []string{"192.0.2.0/25", "192.0.2.64/26", "192.0.2.7/32"} {
+		if _, errs := ValidateClasslessCIDR(cidr, "cidr"); len(errs) > 0 {
+			t.Errorf("ValidateClasslessCIDR(%q) unexpected error: %v", cidr, errs)
+		}
+	}
+	for _, cidr := range []string{"192.0.2.0/24", "10.0.0.0/8", "2001:db8::/64", "192.0.2.0"} {
+		if _, errs := ValidateClasslessCIDR(cidr, "cidr"); len(errs) == 0 {
+			t.Errorf("ValidateClasslessCIDR(%q) expected error but got none", cidr)
+		}
+	}
+}
+
+func TestSplitReverseZoneCIDR(t *testing.T) {
+	tests := []struct {
+		cidr     string
+		expected []string
+	}{
+		{"2001:db8::/48", []string{"2001:db8::/48"}},
+		{"2001:db8::/46", []string{"2001:db8::/48", "2001:db8:1::/48", "2001:db8:2::/48", "2001:db8:3::/48"}},
+		{"2001:db8:8::/45", []string{
+			"2001:db8:8::/48", "2001:db8:9::/48", "2001:db8:a::/48", "2001:db8:b::/48",
+			"2001:db8:c::/48", "2001:db8:d::/48", "2001:db8:e::/48", "2001:db8:f::/48",
+		}},
+		{"2001:db8:0:80::/59", []string{"2001:db8:0:80::/60", "2001:db8:0:90::/60"}},
+		{"10.0.0.0/8", []string{"10.0.0.0/8"}},
+		{"172.16.0.0/15", []string{"172.16.0.0/16", "172.17.0.0/16"}},
+		{"192.0.2.0/23", []string{"192.0.2.0/24", "192.0.3.0/24"}},
+		{"192.0.2.64/26", []string{"192.0.2.64/26"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			blocks, err := SplitReverseZoneCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("SplitReverseZoneCIDR(%q) unexpected error: %v", tt.cidr, err)
+			}
+			if fmt.Sprint(blocks) != fmt.Sprint(tt.expected) {
+				t.Errorf("SplitReverseZoneCIDR(%q) = %v, expected %v", tt.cidr, blocks, tt.expected)
+			}
+		})
+	}
+
+	for _, cidr := range []string{"10.0.0.0/7", "2001:db8::/126", "::/0", "2001:db8::"} {
+		if _, errs := ValidateReverseZonesCIDR(cidr, "cidr"); len(errs) == 0 {
+			t.Errorf("ValidateReverseZonesCIDR(%q) expected error but got none", cidr)
+		}
+	}
+}
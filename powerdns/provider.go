@@ -87,25 +87,45 @@ func Provider() *schema.Provider {
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"powerdns_zone":                  resourcePDNSZone(),
-			"powerdns_view_zone_association": resourcePDNSViewZoneAssociation(),
-			"powerdns_network":               resourcePDNSNetwork(),
-			"powerdns_zone_metadata":         resourcePDNSZoneMetadata(),
-			"powerdns_record":                resourcePDNSRecord(),
-			"powerdns_record_soa":            resourcePDNSRecordSOA(),
-			"powerdns_ptr_record":            resourcePDNSPTRRecord(),
-			"powerdns_reverse_zone":          resourcePDNSReverseZone(),
-			"powerdns_recursor_config":       resourcePDNSRecursorConfig(),
-			"powerdns_recursor_forward_zone": resourcePDNSRecursorForwardZone(),
+			"powerdns_zone":                    resourcePDNSZone(),
+			"powerdns_catalog_zone":            resourcePDNSCatalogZone(),
+			"powerdns_view_zone_association":   resourcePDNSViewZoneAssociation(),
+			"powerdns_network":                 resourcePDNSNetwork(),
+			"powerdns_zone_metadata":           resourcePDNSZoneMetadata(),
+			"powerdns_record":                  resourcePDNSRecord(),
+			"powerdns_host":                    resourcePDNSHost(),
+			"powerdns_record_soa":              resourcePDNSRecordSOA(),
+			"powerdns_ptr_record":              resourcePDNSPTRRecord(),
+			"powerdns_ptr_range":               resourcePDNSPTRRange(),
+			"powerdns_mx_record":               resourcePDNSMXRecord(),
+			"powerdns_srv_record":              resourcePDNSSRVRecord(),
+			"powerdns_caa_record":              resourcePDNSCAARecord(),
+			"powerdns_https_record":            resourcePDNSHTTPSRecord(),
+			"powerdns_reverse_zone":            resourcePDNSReverseZone(),
+			"powerdns_reverse_zones":           resourcePDNSReverseZones(),
+			"powerdns_reverse_zone_delegation": resourcePDNSReverseZoneDelegation(),
+			"powerdns_recursor_config":         resourcePDNSRecursorConfig(),
+			"powerdns_recursor_forward_zone":   resourcePDNSRecursorForwardZone(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"powerdns_reverse_zone":       dataSourcePDNSReverseZone(),
-			"powerdns_record":             dataSourcePDNSRecord(),
-			"powerdns_record_soa":         dataSourcePDNSRecordSOA(),
-			"powerdns_zone":               dataSourcePDNSZone(),
-			"powerdns_zone_metadata":      dataSourcePDNSZoneMetadata(),
-			"powerdns_zone_metadata_list": dataSourcePDNSZoneMetadataList(),
+			"powerdns_reverse_zone":        dataSourcePDNSReverseZone(),
+			"powerdns_catalog_members":     dataSourcePDNSCatalogMembers(),
+			"powerdns_available_ips":       dataSourcePDNSAvailableIPs(),
+			"powerdns_ptr_record":          dataSourcePDNSPTRRecord(),
+			"powerdns_ptr_records":         dataSourcePDNSPTRRecords(),
+			"powerdns_reverse_consistency": dataSourcePDNSReverseConsistency(),
+			"powerdns_search":              dataSourcePDNSSearch(),
+			"powerdns_server":              dataSourcePDNSServer(),
+			"powerdns_server_config":       dataSourcePDNSServerConfig(),
+			"powerdns_statistics":          dataSourcePDNSStatistics(),
+			"powerdns_record":              dataSourcePDNSRecord(),
+			"powerdns_record_soa":          dataSourcePDNSRecordSOA(),
+			"powerdns_zone":                dataSourcePDNSZone(),
+			"powerdns_zone_export":         dataSourcePDNSZoneExport(),
+			"powerdns_zones":               dataSourcePDNSZones(),
+			"powerdns_zone_metadata":       dataSourcePDNSZoneMetadata(),
+			"powerdns_zone_metadata_list":  dataSourcePDNSZoneMetadataList(),
 		},
 
 		ConfigureContextFunc: providerConfigure,
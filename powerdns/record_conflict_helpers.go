@@ -0,0 +1,44 @@
+package powerdns
+
+import (
+	"fmt"
+	"strings"
+)
+
+// cnameCompatibleTypes are the only RRset types PowerDNS lets coexist with a
+// CNAME at the same name; they are DNSSEC records generated by the server.
+var cnameCompatibleTypes = []string{"RRSIG", "NSEC", "NSEC3"}
+
+// findRecordConflict checks a planned RRset against the RRsets already in its
+// zone and returns an error naming the first one PowerDNS would reject it for:
+// a CNAME next to other data, a second CNAME owner at the same name, or a CNAME
+// at the zone apex. The RRset with ownID is the one this resource already
+// manages and is ignored, since it is replaced rather than added to.
+func findRecordConflict(zone string, name string, typ string, recordCount int, ownID string, existing []Record) error {
+	isCNAME := strings.EqualFold(typ, "CNAME")
+
+	if isCNAME && recordCount > 1 {
+		return fmt.Errorf("CNAME RRset %s must hold exactly one record, got %d", name, recordCount)
+	}
+	if isCNAME && strings.EqualFold(name, zoneBaseName(zone)) {
+		return fmt.Errorf("CNAME RRset %s cannot be created at the apex of zone %s, which holds the SOA and NS RRsets", name, zone)
+	}
+
+	for _, record := range existing {
+		if !strings.EqualFold(record.Name, name) || strings.EqualFold(record.ID(), ownID) {
+			continue
+		}
+
+		existingCNAME := strings.EqualFold(record.Type, "CNAME")
+		switch {
+		case isCNAME && existingCNAME:
+			return fmt.Errorf("CNAME RRset %s already exists in zone %s and is not managed by this resource", record.ID(), zone)
+		case isCNAME && !containsFold(cnameCompatibleTypes, record.Type):
+			return fmt.Errorf("CNAME RRset %s conflicts with existing RRset %s in zone %s: a CNAME cannot coexist with other data", name, record.ID(), zone)
+		case existingCNAME && !containsFold(cnameCompatibleTypes, typ):
+			return fmt.Errorf("%s RRset %s conflicts with existing RRset %s in zone %s: a CNAME cannot coexist with other data", strings.ToUpper(typ), name, record.ID(), zone)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package powerdns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindRecordConflict(t *testing.T) {
+	existing := []Record{
+		{Name: "example.com.", Type: "SOA"},
+		{Name: "example.com.", Type: "NS"},
+		{Name: "www.example.com.", Type: "A"},
+		{Name: "www.example.com.", Type: "A"},
+		{Name: "alias.example.com.", Type: "CNAME"},
+		{Name: "alias.example.com.", Type: "RRSIG"},
+	}
+
+	tests := []struct {
+		name        string
+		record      string
+		typ         string
+		recordCount int
+		ownID       string
+		expectedErr string
+	}{
+		{name: "Unrelated name", record: "mail.example.com.", typ: "CNAME", recordCount: 1},
+		{name: "A next to A", record: "www.example.com.", typ: "AAAA", recordCount: 1},
+		{name: "CNAME next to other data", record: "www.example.com.", typ: "CNAME", recordCount: 1, expectedErr: "conflicts with existing RRset www.example.com.:::A"},
+		{name: "CNAME next to other data is case-insensitive", record: "WWW.example.com.", typ: "cname", recordCount: 1, expectedErr: "conflicts with existing RRset www.example.com.:::A"},
+		{name: "Other data next to CNAME", record: "alias.example.com.", typ: "TXT", recordCount: 1, expectedErr: "conflicts with existing RRset alias.example.com.:::CNAME"},
+		{name: "Duplicate CNAME", record: "alias.example.com.", typ: "CNAME", recordCount: 1, expectedErr: "alias.example.com.:::CNAME already exists"},
+		{name: "Own CNAME is ignored", record: "alias.example.com.", typ: "CNAME", recordCount: 1, ownID: "alias.example.com.:::CNAME"},
+		{name: "Own RRset replaced by CNAME is ignored", record: "www.example.com.", typ: "CNAME", recordCount: 1, ownID: "www.example.com.:::A"},
+		{name: "Several CNAME records", record: "new.example.com.", typ: "CNAME", recordCount: 2, expectedErr: "must hold exactly one record"},
+		{name: "CNAME at apex", record: "example.com.", typ: "CNAME", recordCount: 1, expectedErr: "cannot be created at the apex"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := findRecordConflict("example.com.", tt.record, tt.typ, tt.recordCount, tt.ownID, existing)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestFindRecordConflictAtViewVariantApex(t *testing.T) {
+	err := findRecordConflict("example.com..internal", "example.com.", "CNAME", 1, "", nil)
+	assert.ErrorContains(t, err, "cannot be created at the apex")
+}
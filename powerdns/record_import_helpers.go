@@ -0,0 +1,102 @@
+package powerdns
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// recordImportData is the JSON form of a record import ID.
+type recordImportData struct {
+	Zone         *string `json:"zone"`
+	ID           *string `json:"id"`
+	TXTAutoQuote bool    `json:"txt_auto_quote"`
+}
+
+// recordImportIDFormats lists the import ID forms accepted by the record
+// resources, for use in error messages.
+const recordImportIDFormats = `{"zone":"example.com.","id":"www.example.com.:::A"}, ` +
+	`example.com./www.example.com./A or example.com.:::www.example.com.:::A`
+
+// parseRecordImportID parses a record import ID and returns the zone and the
+// record ID (name:::TYPE) it refers to. Three forms are accepted:
+//
+//   - the JSON object {"zone":"example.com.","id":"www.example.com.:::A"}
+//   - zone:::name:::type
+//   - zone/name/type
+//
+// In every form the name may also be relative to the zone, or "@", and the
+// type is uppercased.
+// Reverse names of classless delegations contain slashes, so the slash form
+// is rejected as ambiguous whenever it does not split into exactly three parts.
+func parseRecordImportID(importID string) (string, string, error) {
+	var zone, name, typ string
+
+	switch {
+	case strings.HasPrefix(strings.TrimSpace(importID), "{"):
+		data, err := parseRecordImportJSON(importID)
+		if err != nil {
+			return "", "", err
+		}
+
+		if data.Zone == nil {
+			return "", "", fmt.Errorf("missing zone name in input data")
+		}
+		if data.ID == nil {
+			return "", "", fmt.Errorf("missing record id in input data")
+		}
+		zone = *data.Zone
+		var ok bool
+		if name, typ, ok = strings.Cut(*data.ID, idSeparator); !ok || strings.Contains(typ, idSeparator) {
+			return "", "", fmt.Errorf("invalid record id %q in import ID, expected name%stype", *data.ID, idSeparator)
+		}
+	case strings.Contains(importID, idSeparator):
+		parts := strings.Split(importID, idSeparator)
+		if len(parts) != 3 {
+			return "", "", fmt.Errorf("invalid import ID %q: expected one of %s", importID, recordImportIDFormats)
+		}
+		zone, name, typ = parts[0], parts[1], parts[2]
+	default:
+		parts := strings.Split(importID, "/")
+		if len(parts) != 3 {
+			return "", "", fmt.Errorf("invalid or ambiguous import ID %q: expected one of %s; use the ::: or JSON form for names containing a slash", importID, recordImportIDFormats)
+		}
+		zone, name, typ = parts[0], parts[1], parts[2]
+	}
+
+	if zone == "" || name == "" || typ == "" {
+		return "", "", fmt.Errorf("invalid import ID %q: zone, name and type must not be empty; expected one of %s", importID, recordImportIDFormats)
+	}
+	if _, errs := ValidateZoneName(zone, "zone"); len(errs) > 0 {
+		return "", "", fmt.Errorf("invalid zone in import ID %q: %w", importID, errs[0])
+	}
+
+	name = resolveRecordName(name, zone)
+	if !recordNameInZone(name, zone) {
+		return "", "", fmt.Errorf("record name %s in import ID is not inside zone %s", name, zone)
+	}
+
+	return zone, name + idSeparator + strings.ToUpper(typ), nil
+}
+
+func parseRecordImportJSON(importID string) (recordImportData, error) {
+	var data recordImportData
+	if err := json.Unmarshal([]byte(importID), &data); err != nil {
+		return data, fmt.Errorf("invalid JSON import ID %q: %w; expected one of %s", importID, err, recordImportIDFormats)
+	}
+	return data, nil
+}
+
+// parseRecordImportTXTAutoQuote reports whether an import ID enables
+// txt_auto_quote. Only the JSON form can, through a "txt_auto_quote" field.
+func parseRecordImportTXTAutoQuote(importID string) (bool, error) {
+	if !strings.HasPrefix(strings.TrimSpace(importID), "{") {
+		return false, nil
+	}
+
+	data, err := parseRecordImportJSON(importID)
+	if err != nil {
+		return false, err
+	}
+	return data.TXTAutoQuote, nil
+}
@@ -0,0 +1,122 @@
+package powerdns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRecordImportID(t *testing.T) {
+	tests := []struct {
+		name             string
+		importID         string
+		expectedZone     string
+		expectedRecordID string
+	}{
+		{
+			name:             "JSON",
+			importID:         `{"zone":"example.com.","id":"www.example.com.:::A"}`,
+			expectedZone:     "example.com.",
+			expectedRecordID: "www.example.com.:::A",
+		},
+		{
+			name:             "JSON with relative name and lowercase type",
+			importID:         `{"zone":"example.com.","id":"www:::txt"}`,
+			expectedZone:     "example.com.",
+			expectedRecordID: "www.example.com.:::TXT",
+		},
+		{
+			name:             "JSON with txt_auto_quote",
+			importID:         `{"zone":"example.com.","id":"www:::TXT","txt_auto_quote":true}`,
+			expectedZone:     "example.com.",
+			expectedRecordID: "www.example.com.:::TXT",
+		},
+		{
+			name:             "Separator form",
+			importID:         "example.com.:::www.example.com.:::A",
+			expectedZone:     "example.com.",
+			expectedRecordID: "www.example.com.:::A",
+		},
+		{
+			name:             "Slash form",
+			importID:         "example.com./www.example.com./A",
+			expectedZone:     "example.com.",
+			expectedRecordID: "www.example.com.:::A",
+		},
+		{
+			name:             "Slash form with relative name and lowercase type",
+			importID:         "example.com./www/aaaa",
+			expectedZone:     "example.com.",
+			expectedRecordID: "www.example.com.:::AAAA",
+		},
+		{
+			name:             "Apex",
+			importID:         "example.com./@/SOA",
+			expectedZone:     "example.com.",
+			expectedRecordID: "example.com.:::SOA",
+		},
+		{
+			name:             "View variant",
+			importID:         "example.com..internal:::www.example.com.:::A",
+			expectedZone:     "example.com..internal",
+			expectedRecordID: "www.example.com.:::A",
+		},
+		{
+			name:             "Classless reverse name in separator form",
+			importID:         "0/26.2.0.192.in-addr.arpa.:::10.0/26.2.0.192.in-addr.arpa.:::PTR",
+			expectedZone:     "0/26.2.0.192.in-addr.arpa.",
+			expectedRecordID: "10.0/26.2.0.192.in-addr.arpa.:::PTR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zone, recordID, err := parseRecordImportID(tt.importID)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.expectedZone, zone)
+				assert.Equal(t, tt.expectedRecordID, recordID)
+			}
+		})
+	}
+}
+
+func TestParseRecordImportIDInvalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		importID    string
+		expectedErr string
+	}{
+		{name: "Bare record ID", importID: "www.example.com.:::A", expectedErr: "expected one of"},
+		{name: "Slash form with slash in name", importID: "0/26.2.0.192.in-addr.arpa./10.0/26.2.0.192.in-addr.arpa./PTR", expectedErr: "ambiguous"},
+		{name: "Two slash parts", importID: "example.com./www.example.com.", expectedErr: "expected one of"},
+		{name: "Empty type", importID: "example.com./www.example.com./", expectedErr: "must not be empty"},
+		{name: "Zone without trailing dot", importID: "example.com/www/A", expectedErr: "invalid zone"},
+		{name: "Name outside zone", importID: "example.com./www.example.org./A", expectedErr: "not inside zone"},
+		{name: "Broken JSON", importID: `{"zone":`, expectedErr: "invalid JSON import ID"},
+		{name: "JSON without zone", importID: `{"id":"www.example.com.:::A"}`, expectedErr: "missing zone name"},
+		{name: "JSON without id", importID: `{"zone":"example.com."}`, expectedErr: "missing record id"},
+		{name: "JSON with non-string zone", importID: `{"zone":1,"id":"www.example.com.:::A"}`, expectedErr: "invalid JSON import ID"},
+		{name: "JSON with bad id", importID: `{"zone":"example.com.","id":"www.example.com."}`, expectedErr: "invalid record id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := parseRecordImportID(tt.importID)
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestParseRecordImportTXTAutoQuote(t *testing.T) {
+	autoQuote, err := parseRecordImportTXTAutoQuote(`{"zone":"example.com.","id":"www:::TXT","txt_auto_quote":true}`)
+	assert.NoError(t, err)
+	assert.True(t, autoQuote)
+
+	autoQuote, err = parseRecordImportTXTAutoQuote(`{"zone":"example.com.","id":"www:::TXT"}`)
+	assert.NoError(t, err)
+	assert.False(t, autoQuote)
+
+	autoQuote, err = parseRecordImportTXTAutoQuote("example.com./www/TXT")
+	assert.NoError(t, err)
+	assert.False(t, autoQuote)
+}
@@ -0,0 +1,140 @@
+package powerdns
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// errNoZoneForName is returned by findZoneForName when no zone contains the
+// name.
+var errNoZoneForName = errors.New("no zone found")
+
+// zoneBaseName returns the DNS name a zone is authoritative for, stripping a
+// PowerDNS view variant: "example.com..internal" becomes "example.com." and
+// "..internal" becomes ".".
+func zoneBaseName(zone string) string {
+	base, _, found := strings.Cut(zone, "..")
+	if !found {
+		return zone
+	}
+	return base + "."
+}
+
+// resolveRecordName turns a record name into a fully qualified one. Names with
+// a trailing dot are already absolute; "@" is the zone apex, and any other name
+// is taken as relative to the zone.
+func resolveRecordName(name string, zone string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+
+	base := zoneBaseName(zone)
+	if name == "@" {
+		return base
+	}
+	if base == "." {
+		return name + "."
+	}
+	return name + "." + base
+}
+
+// isRecordNameRelative reports whether a record name needs a zone to resolve.
+func isRecordNameRelative(name string) bool {
+	return !strings.HasSuffix(name, ".")
+}
+
+// recordNameInZone reports whether a fully qualified name is the apex of, or
+// below, the given zone. View variants are compared by their base name.
+func recordNameInZone(name string, zone string) bool {
+	base := strings.ToLower(zoneBaseName(zone))
+	name = strings.ToLower(name)
+
+	return base == "." || name == base || strings.HasSuffix(name, "."+base)
+}
+
+// findZoneForName picks the zone a fully qualified name belongs to by the
+// longest suffix match. View variants of the same zone cannot be told apart
+// from the name alone, so a tie between them is reported as ambiguous.
+func findZoneForName(zones []ZoneInfo, name string) (string, error) {
+	var matches []string
+	longest := -1
+
+	for _, zone := range zones {
+		if !recordNameInZone(name, zone.Name) {
+			continue
+		}
+
+		length := len(zoneBaseName(zone.Name))
+		switch {
+		case length > longest:
+			longest = length
+			matches = []string{zone.Name}
+		case length == longest:
+			matches = append(matches, zone.Name)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("%w that contains %s", errNoZoneForName, name)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("%s matches several zones (%s); set zone explicitly", name, strings.Join(matches, ", "))
+	}
+}
+
+// findReverseZoneForIP picks the reverse zone a PTR record for ip belongs in.
+// An RFC 2317 classless zone whose block holds the address wins over its
+// parent /24; otherwise the PTR name is matched by the longest suffix.
+func findReverseZoneForIP(zones []ZoneInfo, ip string) (string, error) {
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return "", fmt.Errorf("invalid IP address: %s", ip)
+	}
+
+	var classless []string
+	longest := -1
+	for _, zone := range zones {
+		base := zoneBaseName(zone.Name)
+		if !IsClasslessReverseZone(base) {
+			continue
+		}
+		cidr, err := ParseReverseZoneName(base)
+		if err != nil {
+			continue
+		}
+		_, block, _ := net.ParseCIDR(cidr)
+		if !block.Contains(parsedIP) {
+			continue
+		}
+
+		ones, _ := block.Mask.Size()
+		switch {
+		case ones > longest:
+			longest = ones
+			classless = []string{zone.Name}
+		case ones == longest:
+			classless = append(classless, zone.Name)
+		}
+	}
+	switch len(classless) {
+	case 0:
+	case 1:
+		return classless[0], nil
+	default:
+		return "", fmt.Errorf("IP address %s matches several reverse zones (%s); set reverse_zone explicitly", ip, strings.Join(classless, ", "))
+	}
+
+	ptrName, err := GetPTRRecordFQDN(ip, "")
+	if err != nil {
+		return "", err
+	}
+	zone, err := findZoneForName(zones, ptrName)
+	if err != nil {
+		return "", fmt.Errorf("couldn't determine the reverse zone for IP address %s: %w", ip, err)
+	}
+	return zone, nil
+}
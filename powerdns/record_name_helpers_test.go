@@ -0,0 +1,139 @@
+package powerdns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZoneBaseName(t *testing.T) {
+	assert.Equal(t, "example.com.", zoneBaseName("example.com."))
+	assert.Equal(t, "example.com.", zoneBaseName("example.com..internal"))
+	assert.Equal(t, ".", zoneBaseName("..internal"))
+	assert.Equal(t, ".", zoneBaseName("."))
+}
+
+func TestResolveRecordName(t *testing.T) {
+	tests := []struct {
+		name     string
+		record   string
+		zone     string
+		expected string
+	}{
+		{name: "Absolute name", record: "www.example.com.", zone: "example.com.", expected: "www.example.com."},
+		{name: "Apex", record: "@", zone: "example.com.", expected: "example.com."},
+		{name: "Relative name", record: "www", zone: "example.com.", expected: "www.example.com."},
+		{name: "Relative multi-label name", record: "_sip._tcp", zone: "example.com.", expected: "_sip._tcp.example.com."},
+		{name: "Relative name in view variant", record: "www", zone: "example.com..internal", expected: "www.example.com."},
+		{name: "Apex of view variant", record: "@", zone: "example.com..internal", expected: "example.com."},
+		{name: "Relative name in root zone", record: "com", zone: ".", expected: "com."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, resolveRecordName(tt.record, tt.zone))
+		})
+	}
+}
+
+func TestRecordNameInZone(t *testing.T) {
+	tests := []struct {
+		name     string
+		record   string
+		zone     string
+		expected bool
+	}{
+		{name: "Apex", record: "example.com.", zone: "example.com.", expected: true},
+		{name: "Below apex", record: "a.b.example.com.", zone: "example.com.", expected: true},
+		{name: "Case-insensitive", record: "WWW.Example.COM.", zone: "example.com.", expected: true},
+		{name: "Other zone", record: "www.example.org.", zone: "example.com.", expected: false},
+		{name: "Suffix without label boundary", record: "www.badexample.com.", zone: "example.com.", expected: false},
+		{name: "View variant", record: "www.example.com.", zone: "example.com..internal", expected: true},
+		{name: "View variant other zone", record: "www.example.org.", zone: "example.com..internal", expected: false},
+		{name: "Root zone", record: "www.example.com.", zone: ".", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, recordNameInZone(tt.record, tt.zone))
+		})
+	}
+}
+
+func TestFindZoneForName(t *testing.T) {
+	zones := []ZoneInfo{
+		{Name: "example.com."},
+		{Name: "sub.example.com."},
+		{Name: "example.org."},
+	}
+
+	zone, err := findZoneForName(zones, "www.sub.example.com.")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "sub.example.com.", zone)
+	}
+
+	zone, err = findZoneForName(zones, "www.example.com.")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "example.com.", zone)
+	}
+
+	_, err = findZoneForName(zones, "www.example.net.")
+	assert.ErrorIs(t, err, errNoZoneForName)
+	assert.ErrorContains(t, err, "no zone found that contains www.example.net.")
+}
+
+func TestFindZoneForNameAmbiguousVariants(t *testing.T) {
+	zones := []ZoneInfo{
+		{Name: "example.com."},
+		{Name: "example.com..internal"},
+	}
+
+	_, err := findZoneForName(zones, "www.example.com.")
+	assert.ErrorContains(t, err, "set zone explicitly")
+}
+
+func TestFindReverseZoneForIP(t *testing.T) {
+	zones := []ZoneInfo{
+		{Name: "example.com."},
+		{Name: "10.in-addr.arpa."},
+		{Name: "16.172.in-addr.arpa."},
+		{Name: "1.16.172.in-addr.arpa."},
+		{Name: "2.0.192.in-addr.arpa."},
+		{Name: "0/25.2.0.192.in-addr.arpa."},
+		{Name: "64/26.2.0.192.in-addr.arpa."},
+		{Name: "8.b.d.0.1.0.0.2.ip6.arpa."},
+	}
+
+	tests := []struct {
+		name     string
+		ip       string
+		expected string
+	}{
+		{name: "Only /8 covers", ip: "10.1.2.3", expected: "10.in-addr.arpa."},
+		{name: "Delegated /24 wins over /16", ip: "172.16.1.9", expected: "1.16.172.in-addr.arpa."},
+		{name: "Falls back to /16", ip: "172.16.2.9", expected: "16.172.in-addr.arpa."},
+		{name: "Most specific classless zone wins", ip: "192.0.2.70", expected: "64/26.2.0.192.in-addr.arpa."},
+		{name: "Classless zone wins over parent /24", ip: "192.0.2.10", expected: "0/25.2.0.192.in-addr.arpa."},
+		{name: "Address outside classless zones uses parent /24", ip: "192.0.2.200", expected: "2.0.192.in-addr.arpa."},
+		{name: "IPv6", ip: "2001:db8::1", expected: "8.b.d.0.1.0.0.2.ip6.arpa."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zone, err := findReverseZoneForIP(zones, tt.ip)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.expected, zone)
+			}
+		})
+	}
+}
+
+func TestFindReverseZoneForIPNoMatch(t *testing.T) {
+	zones := []ZoneInfo{{Name: "10.in-addr.arpa."}}
+
+	_, err := findReverseZoneForIP(zones, "192.0.2.1")
+	assert.ErrorContains(t, err, "couldn't determine the reverse zone for IP address 192.0.2.1")
+
+	_, err = findReverseZoneForIP(zones, "not-an-ip")
+	assert.ErrorContains(t, err, "invalid IP address")
+}
@@ -0,0 +1,150 @@
+package powerdns
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// txtMaxCharacterStringLength is the largest payload a single DNS
+// character-string can carry (RFC 1035, section 3.3).
+const txtMaxCharacterStringLength = 255
+
+// isTXTAutoQuoteType reports whether txt_auto_quote applies to an RRset type.
+func isTXTAutoQuoteType(typ string) bool {
+	return strings.EqualFold(typ, "TXT") || strings.EqualFold(typ, "SPF")
+}
+
+// validateTXTAutoQuote rejects txt_auto_quote on an RRset type it does not
+// apply to.
+func validateTXTAutoQuote(autoQuote bool, typ string) error {
+	if autoQuote && !isTXTAutoQuoteType(typ) {
+		return fmt.Errorf("txt_auto_quote is only supported for TXT and SPF records, got %s", typ)
+	}
+	return nil
+}
+
+// encodeTXTContent turns a plain string into PowerDNS TXT content.
+//
+// The value is split into character-strings of at most 255 bytes, each one
+// quoted, with backslashes and double quotes escaped. Chunks never end in the
+// middle of a UTF-8 sequence, so a multi-byte character is not torn in two.
+// An empty value becomes a single empty character-string.
+func encodeTXTContent(value string) string {
+	if value == "" {
+		return `""`
+	}
+
+	chunks := make([]string, 0, len(value)/txtMaxCharacterStringLength+1)
+	for len(value) > 0 {
+		end := len(value)
+		if end > txtMaxCharacterStringLength {
+			end = txtMaxCharacterStringLength
+			for end > 0 && !utf8.RuneStart(value[end]) {
+				end--
+			}
+			if end == 0 {
+				end = txtMaxCharacterStringLength
+			}
+		}
+
+		chunks = append(chunks, quoteTXTCharacterString(value[:end]))
+		value = value[end:]
+	}
+
+	return strings.Join(chunks, " ")
+}
+
+func quoteTXTCharacterString(value string) string {
+	var b strings.Builder
+	b.Grow(len(value) + 2)
+	b.WriteByte('"')
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c == '"' || c == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// decodeTXTContent reassembles PowerDNS TXT content into the plain string it
+// represents, joining all character-strings and resolving \X and \DDD escapes.
+// Unquoted character-strings are accepted as well, since zone-file syntax
+// allows them and PowerDNS may hand content back that was written elsewhere.
+func decodeTXTContent(content string) (string, error) {
+	var b strings.Builder
+
+	i := 0
+	for i < len(content) {
+		switch c := content[i]; {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '"':
+			i++
+			closed := false
+			for i < len(content) {
+				if content[i] == '"' {
+					closed = true
+					i++
+					break
+				}
+				n, err := decodeTXTByte(content, i, &b)
+				if err != nil {
+					return "", err
+				}
+				i += n
+			}
+			if !closed {
+				return "", fmt.Errorf("unterminated character-string in TXT content: %s", content)
+			}
+		default:
+			for i < len(content) && content[i] != ' ' && content[i] != '\t' {
+				if content[i] == '"' {
+					return "", fmt.Errorf("unexpected quote in TXT content: %s", content)
+				}
+				n, err := decodeTXTByte(content, i, &b)
+				if err != nil {
+					return "", err
+				}
+				i += n
+			}
+		}
+	}
+
+	return b.String(), nil
+}
+
+// decodeTXTByte writes the byte at content[i] to b, resolving an escape if one
+// starts there, and returns how many input bytes it consumed.
+func decodeTXTByte(content string, i int, b *strings.Builder) (int, error) {
+	if content[i] != '\\' {
+		b.WriteByte(content[i])
+		return 1, nil
+	}
+
+	if i+1 >= len(content) {
+		return 0, fmt.Errorf("dangling escape in TXT content: %s", content)
+	}
+
+	if isASCIIDigit(content[i+1]) {
+		if i+3 >= len(content) || !isASCIIDigit(content[i+2]) || !isASCIIDigit(content[i+3]) {
+			return 0, fmt.Errorf("invalid decimal escape in TXT content: %s", content)
+		}
+		value := int(content[i+1]-'0')*100 + int(content[i+2]-'0')*10 + int(content[i+3]-'0')
+		if value > 255 {
+			return 0, fmt.Errorf("decimal escape out of range in TXT content: %s", content)
+		}
+		b.WriteByte(byte(value))
+		return 4, nil
+	}
+
+	b.WriteByte(content[i+1])
+	return 2, nil
+}
+
+func isASCIIDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,160 @@
+package powerdns
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTXTAutoQuote(t *testing.T) {
+	assert.NoError(t, validateTXTAutoQuote(true, "TXT"))
+	assert.NoError(t, validateTXTAutoQuote(true, "spf"))
+	assert.NoError(t, validateTXTAutoQuote(false, "A"))
+	assert.EqualError(t, validateTXTAutoQuote(true, "A"), "txt_auto_quote is only supported for TXT and SPF records, got A")
+}
+
+func TestEncodeTXTContent(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Plain string",
+			input:    "v=spf1 mx -all",
+			expected: `"v=spf1 mx -all"`,
+		},
+		{
+			name:     "Empty string",
+			input:    "",
+			expected: `""`,
+		},
+		{
+			name:     "Quotes and backslashes are escaped",
+			input:    `say "hi" \o/`,
+			expected: `"say \"hi\" \\o/"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, encodeTXTContent(tt.input))
+		})
+	}
+}
+
+func TestEncodeTXTContentSplitsLongValues(t *testing.T) {
+	value := strings.Repeat("a", 600)
+
+	encoded := encodeTXTContent(value)
+
+	expected := `"` + strings.Repeat("a", 255) + `" "` + strings.Repeat("a", 255) + `" "` + strings.Repeat("a", 90) + `"`
+	assert.Equal(t, expected, encoded)
+}
+
+func TestEncodeTXTContentDoesNotSplitMultiByteCharacters(t *testing.T) {
+	// 254 ASCII bytes followed by a two-byte character: the character must
+	// move to the next chunk instead of being split across the boundary.
+	value := strings.Repeat("a", 254) + "é"
+
+	encoded := encodeTXTContent(value)
+
+	assert.Equal(t, `"`+strings.Repeat("a", 254)+`" "é"`, encoded)
+}
+
+func TestDecodeTXTContent(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Single character-string",
+			input:    `"v=spf1 mx -all"`,
+			expected: "v=spf1 mx -all",
+		},
+		{
+			name:     "Multiple character-strings are joined",
+			input:    `"v=DKIM1; k=rsa; " "p=MIGfMA0"`,
+			expected: "v=DKIM1; k=rsa; p=MIGfMA0",
+		},
+		{
+			name:     "Escapes are resolved",
+			input:    `"say \"hi\" \\o/"`,
+			expected: `say "hi" \o/`,
+		},
+		{
+			name:     "Decimal escapes are resolved",
+			input:    `"caf\195\169"`,
+			expected: "café",
+		},
+		{
+			name:     "Unquoted character-string",
+			input:    `hello`,
+			expected: "hello",
+		},
+		{
+			name:     "Empty character-string",
+			input:    `""`,
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded, err := decodeTXTContent(tt.input)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.expected, decoded)
+			}
+		})
+	}
+}
+
+func TestDecodeTXTContentInvalid(t *testing.T) {
+	tests := []string{
+		`"unterminated`,
+		`"dangling \`,
+		`"bad \12"`,
+		`"out of range \300"`,
+		`un"quoted`,
+	}
+
+	for _, input := range tests {
+		t.Run(input, func(t *testing.T) {
+			_, err := decodeTXTContent(input)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestTXTContentRoundTrip(t *testing.T) {
+	values := []string{
+		"",
+		"v=spf1 include:_spf.example.com ~all",
+		`quote " and backslash \`,
+		strings.Repeat("0123456789", 100),
+		strings.Repeat("é", 300),
+	}
+
+	for _, value := range values {
+		decoded, err := decodeTXTContent(encodeTXTContent(value))
+		if assert.NoError(t, err) {
+			assert.Equal(t, value, decoded)
+		}
+	}
+}
+
+func TestFlattenRecordContents(t *testing.T) {
+	records := []Record{{Content: `"first " "second"`}}
+
+	raw, err := flattenRecordContents(records, false)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{`"first " "second"`}, raw)
+	}
+
+	decoded, err := flattenRecordContents(records, true)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"first second"}, decoded)
+	}
+}
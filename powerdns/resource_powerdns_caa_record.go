@@ -0,0 +1,78 @@
+package powerdns
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var caaRecordType = structuredRecordType{
+	types: []string{"CAA"},
+	record: map[string]*schema.Schema{
+		"flags": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      0,
+			ValidateFunc: validation.IntBetween(0, 255),
+			Description:  "The CAA flags byte. Use 128 to mark the property as critical.",
+		},
+		"tag": {
+			Type:     schema.TypeString,
+			Required: true,
+			ValidateFunc: validation.StringMatch(
+				regexp.MustCompile(`^[a-z0-9]+$`),
+				"must contain only lowercase letters and digits, e.g. issue, issuewild or iodef",
+			),
+			Description: "The property tag, for example issue, issuewild or iodef.",
+		},
+		"value": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The property value as a plain string. The provider quotes and escapes it.",
+		},
+	},
+	build: buildCAAContent,
+	parse: parseCAAContent,
+}
+
+func resourcePDNSCAARecord() *schema.Resource {
+	return resourcePDNSStructuredRecord(caaRecordType)
+}
+
+func buildCAAContent(record map[string]interface{}) (string, error) {
+	return fmt.Sprintf("%d %s %s",
+		record["flags"].(int),
+		record["tag"].(string),
+		quoteTXTCharacterString(record["value"].(string)),
+	), nil
+}
+
+func parseCAAContent(content string) (map[string]interface{}, error) {
+	fields, err := splitRecordContentFields(content)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) != 3 {
+		return nil, fmt.Errorf("expected 3 fields, got %d", len(fields))
+	}
+
+	flags, err := strconv.ParseUint(fields[0], 10, 8)
+	if err != nil {
+		return nil, fmt.Errorf("invalid flags %q: %w", fields[0], err)
+	}
+
+	value, err := decodeTXTContent(fields[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %q: %w", fields[2], err)
+	}
+
+	return map[string]interface{}{
+		"flags": int(flags),
+		"tag":   strings.ToLower(fields[1]),
+		"value": value,
+	}, nil
+}
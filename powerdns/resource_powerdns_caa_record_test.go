@@ -0,0 +1,80 @@
+package powerdns
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildCAAContent(t *testing.T) {
+	content, err := buildCAAContent(map[string]interface{}{"flags": 0, "tag": "issue", "value": "letsencrypt.org"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, `0 issue "letsencrypt.org"`, content)
+	}
+}
+
+func TestParseCAAContentLowercasesTag(t *testing.T) {
+	parsed, err := parseCAAContent(`0 ISSUEWILD ";"`)
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]interface{}{"flags": 0, "tag": "issuewild", "value": ";"}, parsed)
+	}
+}
+
+func TestAccPDNSCAARecord_Basic(t *testing.T) {
+	resourceName := "powerdns_caa_record.test"
+	resourceID := `{"zone":"rec-caa-typed.sysa.xyz.","id":"rec-caa-typed.sysa.xyz.:::CAA"}`
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSRecordDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testPDNSCAARecordConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPDNSRecordExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "record.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "record.*", map[string]string{
+						"flags": "0",
+						"tag":   "issue",
+						"value": "letsencrypt.org",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "record.*", map[string]string{
+						"flags": "0",
+						"tag":   "iodef",
+						"value": "mailto:security@sysa.xyz",
+					}),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportStateId:     resourceID,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testPDNSCAARecordConfig = `
+resource "powerdns_zone" "test-zone" {
+	name = "rec-caa-typed.sysa.xyz."
+	kind = "Native"
+}
+
+resource "powerdns_caa_record" "test" {
+	zone = powerdns_zone.test-zone.name
+	name = "rec-caa-typed.sysa.xyz."
+	ttl = 60
+
+	record {
+		tag = "issue"
+		value = "letsencrypt.org"
+	}
+
+	record {
+		tag = "iodef"
+		value = "mailto:security@sysa.xyz"
+	}
+}`
@@ -0,0 +1,365 @@
+package powerdns
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// catalogZoneKinds are the zone kinds of catalog zones (RFC 9432). A Producer
+// catalog lists the zones that have it as their catalog; a Consumer catalog
+// is transferred from a primary, which provisions its members.
+var catalogZoneKinds = []string{"Producer", "Consumer"}
+
+// resourcePDNSCatalogZone manages a Producer or Consumer catalog zone.
+func resourcePDNSCatalogZone() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePDNSCatalogZoneCreate,
+		ReadContext:   resourcePDNSCatalogZoneRead,
+		UpdateContext: resourcePDNSCatalogZoneUpdate,
+		DeleteContext: resourcePDNSCatalogZoneDelete,
+		CustomizeDiff: resourcePDNSCatalogZoneCustomizeDiff,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcePDNSCatalogZoneImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: ValidateZoneName,
+				Description:  "The name of the catalog zone.",
+			},
+			"kind": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(catalogZoneKinds, true),
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.EqualFold(old, new)
+				},
+				Description: "The kind of the catalog zone: Producer or Consumer.",
+			},
+			"account": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "admin",
+				ValidateFunc: validation.StringLenBetween(0, 40),
+				Description:  "The account that owns the catalog zone.",
+			},
+			"masters": zoneMastersSchema(),
+			"members": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The names of the zones that are members of the catalog, sorted.",
+			},
+			"force_destroy":       zoneForceDestroySchema(),
+			"deletion_protection": zoneDeletionProtectionSchema(),
+		},
+	}
+}
+
+func resourcePDNSCatalogZoneCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderClients)
+
+	zoneInfo := ZoneInfo{
+		Name:    d.Get("name").(string),
+		Kind:    d.Get("kind").(string),
+		Account: d.Get("account").(string),
+		Masters: expandStringSet(d.Get("masters").(*schema.Set)),
+	}
+	if err := validateCatalogZoneMasters(zoneInfo.Kind, zoneInfo.Masters); err != nil {
+		return diag.FromErr(err)
+	}
+
+	ctx = tflog.SetField(ctx, "zone", zoneInfo.Name)
+	tflog.Debug(ctx, "Creating catalog zone", map[string]any{"kind": zoneInfo.Kind})
+
+	createdZoneInfo, err := client.PDNS.CreateZone(ctx, zoneInfo)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(createdZoneInfo.ID)
+	tflog.Info(ctx, "Created catalog zone", map[string]any{"id": createdZoneInfo.ID})
+	return resourcePDNSCatalogZoneRead(ctx, d, meta)
+}
+
+func resourcePDNSCatalogZoneRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderClients)
+
+	ctx = tflog.SetField(ctx, "zone", d.Id())
+	tflog.Debug(ctx, "Reading catalog zone")
+
+	zoneInfo, err := client.PDNS.GetZone(ctx, d.Id())
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't fetch catalog zone: %w", err))
+	}
+	if zoneInfo.Name == "" {
+		tflog.Warn(ctx, "Catalog zone not found; removing from state")
+		d.SetId("")
+		return nil
+	}
+
+	zones, err := client.PDNS.ListZones(ctx)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't list zones to find the members of %s: %w", zoneInfo.Name, err))
+	}
+	members := []string{}
+	for _, zone := range catalogMembers(zones, zoneInfo.Name) {
+		members = append(members, zone.Name)
+	}
+
+	if err := d.Set("name", zoneInfo.Name); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting name: %w", err))
+	}
+	if err := d.Set("kind", zoneInfo.Kind); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting kind: %w", err))
+	}
+	if err := d.Set("account", zoneInfo.Account); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting account: %w", err))
+	}
+	if strings.EqualFold(zoneInfo.Kind, "Consumer") {
+		if err := d.Set("masters", zoneInfo.Masters); err != nil {
+			return diag.FromErr(fmt.Errorf("error setting masters: %w", err))
+		}
+	}
+	if err := d.Set("members", members); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting members: %w", err))
+	}
+	if err := setZoneDeletionDefaults(d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourcePDNSCatalogZoneUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderClients)
+
+	ctx = tflog.SetField(ctx, "zone", d.Id())
+	tflog.Debug(ctx, "Updating catalog zone")
+
+	if d.HasChanges("kind", "account", "masters") {
+		zoneInfo := ZoneInfoUpd{
+			Name:    d.Get("name").(string),
+			Kind:    d.Get("kind").(string),
+			Account: d.Get("account").(string),
+			Masters: expandStringSet(d.Get("masters").(*schema.Set)),
+		}
+		if err := validateCatalogZoneMasters(zoneInfo.Kind, zoneInfo.Masters); err != nil {
+			return diag.FromErr(err)
+		}
+		// As for powerdns_zone, the primaries of a catalog that stops
+		// being a Consumer are removed explicitly.
+		oldKind, _ := d.GetChange("kind")
+		if d.HasChange("kind") && strings.EqualFold(oldKind.(string), "Consumer") {
+			zoneInfo.Masters = []string{}
+		}
+		if err := client.PDNS.UpdateZone(ctx, d.Id(), zoneInfo); err != nil {
+			return diag.FromErr(fmt.Errorf("error updating catalog zone: %w", err))
+		}
+	}
+
+	return resourcePDNSCatalogZoneRead(ctx, d, meta)
+}
+
+func resourcePDNSCatalogZoneDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderClients)
+
+	ctx = tflog.SetField(ctx, "zone", d.Id())
+	tflog.Debug(ctx, "Deleting catalog zone")
+
+	// The records of a catalog zone only describe its members, so only
+	// the members are checked, not the records.
+	if err := checkZoneDeletionProtection(d.Id(), d); err != nil {
+		return diag.FromErr(err)
+	}
+	// Deleting a catalog leaves its members in place, but drops them from
+	// every secondary that consumes the catalog.
+	if !d.Get("force_destroy").(bool) {
+		zones, err := client.PDNS.ListZones(ctx)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("couldn't list zones to find the members of %s: %w", d.Id(), err))
+		}
+		if members := catalogMembers(zones, d.Get("name").(string)); len(members) > 0 {
+			return diag.FromErr(fmt.Errorf("catalog zone %s still has %d members; remove them from the catalog or set force_destroy = true to delete it", d.Id(), len(members)))
+		}
+	}
+
+	if err := client.PDNS.DeleteZone(ctx, d.Id()); err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting catalog zone: %w", err))
+	}
+	tflog.Info(ctx, "Deleted catalog zone")
+	return nil
+}
+
+// resourcePDNSCatalogZoneCustomizeDiff checks at plan time that a Consumer
+// catalog has primaries to transfer it from, and a Producer catalog has none.
+func resourcePDNSCatalogZoneCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("kind") || !d.NewValueKnown("masters") {
+		return nil
+	}
+	return validateCatalogZoneMasters(d.Get("kind").(string), expandStringSet(d.Get("masters").(*schema.Set)))
+}
+
+func validateCatalogZoneMasters(kind string, masters []string) error {
+	switch {
+	case strings.EqualFold(kind, "Consumer") && len(masters) == 0:
+		return fmt.Errorf("masters must be set for a Consumer catalog zone")
+	case strings.EqualFold(kind, "Producer") && len(masters) > 0:
+		return fmt.Errorf("masters is supported only for a Consumer catalog zone")
+	}
+	return nil
+}
+
+// resourcePDNSCatalogZoneImport accepts the name of a Producer or Consumer
+// zone.
+func resourcePDNSCatalogZoneImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*ProviderClients)
+
+	tflog.Info(ctx, "Importing catalog zone", map[string]any{"zone": d.Id()})
+
+	zoneInfo, err := client.PDNS.GetZone(ctx, d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("couldn't fetch zone %s: %w", d.Id(), err)
+	}
+	if !isCatalogZoneKind(zoneInfo.Kind) {
+		return nil, fmt.Errorf("zone %s is a %s zone, not a catalog zone", d.Id(), zoneInfo.Kind)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func isCatalogZoneKind(kind string) bool {
+	for _, k := range catalogZoneKinds {
+		if strings.EqualFold(k, kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// catalogMembers returns the zones whose catalog is catalog, sorted by name.
+func catalogMembers(zones []ZoneInfo, catalog string) []ZoneInfo {
+	return filterZones(zones, zoneFilter{catalog: catalog})
+}
+
+// findCatalogZone returns the zone named catalog, and whether it exists.
+func findCatalogZone(zones []ZoneInfo, catalog string) (ZoneInfo, bool) {
+	for _, zone := range zones {
+		if strings.EqualFold(zone.Name, catalog) {
+			return zone, true
+		}
+	}
+	return ZoneInfo{}, false
+}
+
+// checkZoneCatalog returns an error unless catalog is the name of an
+// existing Producer zone.
+func checkZoneCatalog(ctx context.Context, client *PowerDNSClient, catalog string) error {
+	zones, err := client.ListZones(ctx)
+	if err != nil {
+		return fmt.Errorf("couldn't list zones to check catalog %s: %w", catalog, err)
+	}
+	zone, found := findCatalogZone(zones, catalog)
+	if !found {
+		return fmt.Errorf("catalog zone %s does not exist", catalog)
+	}
+	return checkCatalogZoneKind(zone)
+}
+
+func checkCatalogZoneKind(zone ZoneInfo) error {
+	if !strings.EqualFold(zone.Kind, "Producer") {
+		return fmt.Errorf("catalog %s is a %s zone; zones can only be added to a Producer catalog zone", zone.Name, zone.Kind)
+	}
+	return nil
+}
+
+// customizeZoneCatalogDiff checks a changed catalog at plan time. A catalog
+// that does not exist yet is allowed, so it can be created in the same
+// apply; it is checked again when the change is applied.
+func customizeZoneCatalogDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("catalog") || !d.NewValueKnown("catalog") {
+		return nil
+	}
+	catalog := d.Get("catalog").(string)
+	if catalog == "" {
+		return nil
+	}
+
+	client := meta.(*ProviderClients)
+	zones, err := client.PDNS.ListZones(ctx)
+	if err != nil {
+		return fmt.Errorf("couldn't list zones to check catalog %s: %w", catalog, err)
+	}
+	zone, found := findCatalogZone(zones, catalog)
+	if !found {
+		tflog.Debug(ctx, "Catalog zone does not exist yet; checking it on apply", map[string]any{"catalog": catalog})
+		return nil
+	}
+	return checkCatalogZoneKind(zone)
+}
+
+// catalogMemberProperties are the properties of a member zone in the records
+// of a catalog zone.
+type catalogMemberProperties struct {
+	unique string
+	groups []string
+	coo    string
+}
+
+// parseCatalogMemberProperties reads the member properties from the RRsets of
+// a catalog zone, keyed by lower case member name. Members are the PTR
+// records at <unique>.zones.<catalog>; their group TXT and coo PTR records
+// sit one label below.
+func parseCatalogMemberProperties(catalog string, rrSets []ResourceRecordSet) map[string]*catalogMemberProperties {
+	zonesSuffix := ".zones." + strings.ToLower(catalog)
+	byUnique := map[string]*catalogMemberProperties{}
+	members := map[string]*catalogMemberProperties{}
+
+	// The labels below zones.<catalog>, or nil for other names.
+	labels := func(name string) []string {
+		prefix, ok := strings.CutSuffix(strings.ToLower(name), zonesSuffix)
+		if !ok || prefix == "" {
+			return nil
+		}
+		return strings.Split(prefix, ".")
+	}
+
+	for _, rrSet := range rrSets {
+		l := labels(rrSet.Name)
+		if len(l) != 1 || !strings.EqualFold(rrSet.Type, "PTR") || len(rrSet.Records) == 0 {
+			continue
+		}
+		properties := &catalogMemberProperties{unique: l[0]}
+		byUnique[l[0]] = properties
+		members[strings.ToLower(rrSet.Records[0].Content)] = properties
+	}
+
+	for _, rrSet := range rrSets {
+		l := labels(rrSet.Name)
+		if len(l) != 2 || byUnique[l[1]] == nil {
+			continue
+		}
+		properties := byUnique[l[1]]
+		switch {
+		case l[0] == "group" && strings.EqualFold(rrSet.Type, "TXT"):
+			for _, record := range rrSet.Records {
+				properties.groups = append(properties.groups, strings.Trim(record.Content, `"`))
+			}
+			sort.Strings(properties.groups)
+		case l[0] == "coo" && strings.EqualFold(rrSet.Type, "PTR") && len(rrSet.Records) > 0:
+			properties.coo = rrSet.Records[0].Content
+		}
+	}
+
+	return members
+}
@@ -0,0 +1,249 @@
+package powerdns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCatalogZoneMasters(t *testing.T) {
+	assert.NoError(t, validateCatalogZoneMasters("Producer", nil))
+	assert.NoError(t, validateCatalogZoneMasters("consumer", []string{"192.0.2.1"}))
+	assert.ErrorContains(t, validateCatalogZoneMasters("Consumer", nil), "masters must be set")
+	assert.ErrorContains(t, validateCatalogZoneMasters("producer", []string{"192.0.2.1"}), "only for a Consumer")
+}
+
+func TestCheckZoneCatalog(t *testing.T) {
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusOK, `[
+			{"id": "catalog.example.", "name": "catalog.example.", "kind": "Producer"},
+			{"id": "native.example.", "name": "native.example.", "kind": "Native"}
+		]`), nil
+	})
+
+	assert.NoError(t, checkZoneCatalog(context.Background(), client, "Catalog.Example."))
+	assert.ErrorContains(t, checkZoneCatalog(context.Background(), client, "native.example."), "is a Native zone")
+	assert.ErrorContains(t, checkZoneCatalog(context.Background(), client, "missing.example."), "does not exist")
+}
+
+func TestCatalogZoneDeleteIgnoresRecords(t *testing.T) {
+	var deleted bool
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/servers/localhost/zones":
+			return jsonResponse(http.StatusOK, `[
+				{"id": "catalog.example.", "name": "catalog.example.", "kind": "Producer"},
+				{"id": "native.example.", "name": "native.example.", "kind": "Native", "catalog": "other.example."}
+			]`), nil
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/servers/localhost/zones/catalog.example.":
+			deleted = true
+			return &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody, Header: make(http.Header)}, nil
+		}
+		// The RRsets of the catalog, which PowerDNS keeps for itself, are
+		// never fetched.
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		return jsonResponse(http.StatusNotFound, `{"error": "Not Found"}`), nil
+	})
+	meta := &ProviderClients{PDNS: client}
+	config := map[string]interface{}{"name": "catalog.example.", "kind": "Producer"}
+
+	d := schema.TestResourceDataRaw(t, resourcePDNSCatalogZone().Schema, config)
+	d.SetId("catalog.example.")
+	assert.False(t, resourcePDNSCatalogZoneDelete(context.Background(), d, meta).HasError())
+	assert.True(t, deleted)
+
+	deleted = false
+	config["deletion_protection"] = true
+	d = schema.TestResourceDataRaw(t, resourcePDNSCatalogZone().Schema, config)
+	d.SetId("catalog.example.")
+	diags := resourcePDNSCatalogZoneDelete(context.Background(), d, meta)
+	if assert.True(t, diags.HasError()) {
+		assert.Contains(t, diags[0].Summary, "deletion_protection enabled")
+	}
+	assert.False(t, deleted)
+}
+
+func TestCatalogZoneLeavingConsumerClearsMasters(t *testing.T) {
+	var update map[string]interface{}
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		switch {
+		case r.Method == http.MethodPut:
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+			return jsonResponse(http.StatusNoContent, ``), nil
+		case r.URL.Path == "/api/v1/servers/localhost/zones":
+			return jsonResponse(http.StatusOK, `[{"id": "catalog.example.", "name": "catalog.example.", "kind": "Producer"}]`), nil
+		default:
+			return jsonResponse(http.StatusOK, `{"id": "catalog.example.", "name": "catalog.example.", "kind": "Producer", "account": "admin"}`), nil
+		}
+	})
+
+	state := &terraform.InstanceState{
+		ID: "catalog.example.",
+		Attributes: map[string]string{
+			"id":        "catalog.example.",
+			"name":      "catalog.example.",
+			"kind":      "Consumer",
+			"account":   "admin",
+			"masters.#": "1",
+			fmt.Sprintf("masters.%d", schema.HashString("192.0.2.53")): "192.0.2.53",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "catalog.example.",
+		"kind": "Producer",
+	})
+	sm := schema.InternalMap(resourcePDNSCatalogZone().Schema)
+	diff, err := sm.Diff(context.Background(), state, config, nil, nil, true)
+	if !assert.NoError(t, err) {
+		return
+	}
+	d, err := sm.Data(state, diff)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	diags := resourcePDNSCatalogZoneUpdate(context.Background(), d, &ProviderClients{PDNS: client})
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "Producer", update["kind"])
+	assert.Equal(t, []interface{}{}, update["masters"])
+}
+
+func TestParseCatalogMemberProperties(t *testing.T) {
+	rrSets := []ResourceRecordSet{
+		{Name: "catalog.example.", Type: "SOA", Records: []Record{{Content: "invalid. hostmaster.invalid. 1 3600 600 604800 3600"}}},
+		{Name: "version.catalog.example.", Type: "TXT", Records: []Record{{Content: `"2"`}}},
+		{Name: "a1b2.zones.catalog.example.", Type: "PTR", Records: []Record{{Content: "one.example.com."}}},
+		{Name: "group.a1b2.zones.catalog.example.", Type: "TXT", Records: []Record{{Content: `"web"`}, {Content: `"dnssec"`}}},
+		{Name: "coo.a1b2.zones.catalog.example.", Type: "PTR", Records: []Record{{Content: "old-catalog.example."}}},
+		{Name: "c3d4.zones.catalog.example.", Type: "PTR", Records: []Record{{Content: "Two.Example.com."}}},
+		{Name: "group.ffff.zones.catalog.example.", Type: "TXT", Records: []Record{{Content: `"orphan"`}}},
+	}
+
+	properties := parseCatalogMemberProperties("catalog.example.", rrSets)
+	assert.Len(t, properties, 2)
+	if one := properties["one.example.com."]; assert.NotNil(t, one) {
+		assert.Equal(t, "a1b2", one.unique)
+		assert.Equal(t, []string{"dnssec", "web"}, one.groups)
+		assert.Equal(t, "old-catalog.example.", one.coo)
+	}
+	if two := properties["two.example.com."]; assert.NotNil(t, two) {
+		assert.Equal(t, "c3d4", two.unique)
+		assert.Empty(t, two.groups)
+		assert.Empty(t, two.coo)
+	}
+}
+
+func TestAccPDNSCatalogZone(t *testing.T) {
+	resourceName := "powerdns_catalog_zone.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSCatalogZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testPDNSCatalogZoneConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "catalog-test.sysa.xyz."),
+					resource.TestCheckResourceAttr(resourceName, "kind", "Producer"),
+					resource.TestCheckResourceAttr("powerdns_zone.member", "catalog", "catalog-test.sysa.xyz."),
+				),
+			},
+			{
+				// The member is created after the catalog, so it shows up
+				// in members after a refresh.
+				Config: testPDNSCatalogZoneConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "members.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "members.0", "member.catalog-test.sysa.xyz."),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"force_destroy"},
+			},
+		},
+	})
+}
+
+func TestAccPDNSCatalogZoneConsumerWithoutMasters(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSCatalogZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "powerdns_catalog_zone" "test" {
+	name = "consumer-test.sysa.xyz."
+	kind = "Consumer"
+}`,
+				ExpectError: regexp.MustCompile("masters must be set for a Consumer catalog zone"),
+			},
+		},
+	})
+}
+
+func TestAccPDNSZoneCatalogNotProducer(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "powerdns_zone" "not-a-catalog" {
+	name = "not-a-catalog.sysa.xyz."
+	kind = "Native"
+}
+
+resource "powerdns_zone" "member" {
+	name    = "member.not-a-catalog.sysa.xyz."
+	kind    = "Native"
+	catalog = powerdns_zone.not-a-catalog.name
+}`,
+				ExpectError: regexp.MustCompile(`catalog not-a-catalog\.sysa\.xyz\. is a Native zone`),
+			},
+		},
+	})
+}
+
+func testAccCheckPDNSCatalogZoneDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ProviderClients).PDNS
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "powerdns_catalog_zone" && rs.Type != "powerdns_zone" {
+			continue
+		}
+
+		exists, err := client.ZoneExists(context.Background(), rs.Primary.Attributes["name"])
+		if err != nil {
+			return fmt.Errorf("Error checking if zone still exists: %#v", rs.Primary.ID)
+		}
+		if exists {
+			return fmt.Errorf("Zone still exists: %#v", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+const testPDNSCatalogZoneConfig = `
+resource "powerdns_catalog_zone" "test" {
+	name = "catalog-test.sysa.xyz."
+	kind = "Producer"
+}
+
+resource "powerdns_zone" "member" {
+	name    = "member.catalog-test.sysa.xyz."
+	kind    = "Master"
+	catalog = powerdns_catalog_zone.test.name
+}`
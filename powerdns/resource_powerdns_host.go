@@ -0,0 +1,538 @@
+package powerdns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourcePDNSHost manages a hostname together with its addresses: the A and
+// AAAA RRsets in the forward zone and one PTR record per address in the
+// matching reverse zone. Unlike the set_ptr flag of powerdns_record it works
+// on every PowerDNS version and removes PTR records it no longer needs.
+func resourcePDNSHost() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePDNSHostCreate,
+		ReadContext:   resourcePDNSHostRead,
+		UpdateContext: resourcePDNSHostUpdate,
+		DeleteContext: resourcePDNSHostDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcePDNSHostImport,
+		},
+
+		CustomizeDiff: resourcePDNSHostCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: ValidateFQDN,
+				Description:  "The fully qualified hostname, ending with a trailing dot.",
+			},
+			"zone": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: ValidateZoneName,
+				Description:  "The forward zone to contain the A and AAAA records. If omitted, the zone is inferred from name by the longest matching zone on the server.",
+			},
+			"ip_addresses": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.Any(validation.IsIPv4Address, validation.IsIPv6Address),
+				},
+				Description: "The IPv4 and IPv6 addresses of the host. Each one gets an A or AAAA record and a PTR record.",
+			},
+			"ttl": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The TTL of the forward and PTR records.",
+			},
+			"reverse_zones": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The reverse zone holding the PTR record of each address, keyed by address.",
+			},
+		},
+	}
+}
+
+func resourcePDNSHostCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourcePDNSHostUpsert(ctx, d, meta)
+}
+
+func resourcePDNSHostUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourcePDNSHostUpsert(ctx, d, meta)
+}
+
+// resourcePDNSHostUpsert writes the forward RRsets and the PTR records of all
+// addresses, then removes the PTR records of addresses that were dropped.
+// Every record is checked before anything is written, so a new host never
+// takes over A or AAAA records that already exist, and no host takes over a
+// PTR record that belongs to another name.
+func resourcePDNSHostUpsert(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderClients)
+
+	name := d.Get("name").(string)
+	zone := d.Get("zone").(string)
+	ttl := d.Get("ttl").(int)
+	ipAddresses := expandStringSet(d.Get("ip_addresses").(*schema.Set))
+
+	tflog.SetField(ctx, "name", name)
+	tflog.Debug(ctx, "Creating PowerDNS host")
+
+	zones, err := client.PDNS.ListZones(ctx)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't list zones to find the reverse zones of %s: %w", name, err))
+	}
+	if zone == "" {
+		// The plan could not infer the zone because it did not exist yet.
+		if zone, err = findZoneForName(zones, name); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("zone", zone); err != nil {
+			return diag.FromErr(fmt.Errorf("error setting inferred zone: %w", err))
+		}
+	}
+	tflog.SetField(ctx, "zone", zone)
+
+	if d.Id() == "" {
+		forward, err := listHostAddresses(ctx, client.PDNS, zone, name)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := checkHostForwardOwnership(name, forward); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	reverseZones := make(map[string]string, len(ipAddresses))
+	ptrChanges := map[string][]ResourceRecordSet{}
+	for _, ip := range ipAddresses {
+		reverseZone, err := findReverseZoneForIP(zones, ip)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		ptrName, err := GetPTRRecordFQDN(ip, reverseZone)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to determine PTR record name: %w", err))
+		}
+
+		existing, err := client.PDNS.ListRecordsInRRSet(ctx, reverseZone, ptrName, "PTR")
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("couldn't fetch PTR record %s: %w", ptrName, err))
+		}
+		if err := checkPTROwnership(ptrName, existing, name); err != nil {
+			return diag.FromErr(err)
+		}
+
+		reverseZones[ip] = reverseZone
+		ptrChanges[reverseZone] = append(ptrChanges[reverseZone], ResourceRecordSet{
+			Name:       ptrName,
+			Type:       "PTR",
+			TTL:        ttl,
+			ChangeType: "REPLACE",
+			Records:    []Record{{Content: name, TTL: ttl}},
+		})
+	}
+
+	if err := client.PDNS.PatchRecordSets(ctx, zone, hostForwardRRSets(name, ttl, ipAddresses)); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to create forward records of %s: %w", name, err))
+	}
+
+	// On create, the ID is set as soon as the forward records exist, so a
+	// failed PTR write leaves the host in state for destroy to clean up.
+	// Delete only removes PTR records that point at the host, so recording
+	// the reverse zones before they are written is safe.
+	if d.Id() == "" {
+		if err := d.Set("reverse_zones", reverseZones); err != nil {
+			return diag.FromErr(fmt.Errorf("error setting reverse_zones: %w", err))
+		}
+		d.SetId(name)
+	}
+
+	for _, reverseZone := range sortedKeys(ptrChanges) {
+		if err := client.PDNS.PatchRecordSets(ctx, reverseZone, ptrChanges[reverseZone]); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to create PTR records of %s in zone %s: %w", name, reverseZone, err))
+		}
+	}
+
+	// PTR records of addresses that were removed, or whose reverse zone
+	// changed, are cleaned up once the new ones are in place.
+	oldRaw, _ := d.GetChange("reverse_zones")
+	oldReverseZones := expandStringMap(oldRaw.(map[string]interface{}))
+	for _, ip := range sortedKeys(oldReverseZones) {
+		if reverseZones[ip] == oldReverseZones[ip] {
+			continue
+		}
+		if err := deleteOwnedPTRRecord(ctx, client.PDNS, oldReverseZones[ip], ip, name); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := d.Set("reverse_zones", reverseZones); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting reverse_zones: %w", err))
+	}
+	tflog.Info(ctx, "Created PowerDNS host", map[string]any{"id": d.Id()})
+
+	return resourcePDNSHostRead(ctx, d, meta)
+}
+
+func resourcePDNSHostRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderClients)
+
+	name := d.Id()
+	zone := d.Get("zone").(string)
+	tflog.SetField(ctx, "name", name)
+	tflog.SetField(ctx, "zone", zone)
+	tflog.Debug(ctx, "Reading PowerDNS host")
+
+	forward, err := listHostAddresses(ctx, client.PDNS, zone, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(forward) == 0 {
+		tflog.Warn(ctx, "PowerDNS host has no A or AAAA records; removing from state")
+		d.SetId("")
+		return nil
+	}
+
+	// An address whose PTR record is missing or points elsewhere is left out
+	// of state, so the next plan shows it being added again.
+	reverseZones := expandStringMap(d.Get("reverse_zones").(map[string]interface{}))
+	ipAddresses := make([]string, 0, len(forward))
+	keptReverseZones := make(map[string]string, len(forward))
+	for _, record := range forward {
+		ip := matchConfiguredIP(record.Content, reverseZones)
+		reverseZone, ok := reverseZones[ip]
+		if ok {
+			ptrName, err := GetPTRRecordFQDN(ip, reverseZone)
+			if err != nil {
+				return diag.FromErr(fmt.Errorf("failed to determine PTR record name: %w", err))
+			}
+			ptrs, err := listHostPTRRecords(ctx, client.PDNS, reverseZone, ptrName)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if !ptrOwnedBy(ptrs, name) {
+				tflog.Warn(ctx, "PTR record of host address missing or changed", map[string]any{
+					"ip_address": ip,
+					"ptr_name":   ptrName,
+				})
+				continue
+			}
+			keptReverseZones[ip] = reverseZone
+		}
+		ipAddresses = append(ipAddresses, ip)
+	}
+
+	if err := d.Set("name", name); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting name: %w", err))
+	}
+	if err := d.Set("ttl", forward[0].TTL); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting ttl: %w", err))
+	}
+	if err := d.Set("ip_addresses", ipAddresses); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting ip_addresses: %w", err))
+	}
+	if err := d.Set("reverse_zones", keptReverseZones); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting reverse_zones: %w", err))
+	}
+
+	return nil
+}
+
+func resourcePDNSHostDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderClients)
+
+	name := d.Id()
+	zone := d.Get("zone").(string)
+	tflog.SetField(ctx, "name", name)
+	tflog.SetField(ctx, "zone", zone)
+	tflog.Debug(ctx, "Deleting PowerDNS host")
+
+	if err := client.PDNS.PatchRecordSets(ctx, zone, hostForwardRRSets(name, 0, nil)); err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting forward records of %s: %w", name, err))
+	}
+
+	reverseZones := expandStringMap(d.Get("reverse_zones").(map[string]interface{}))
+	for _, ip := range sortedKeys(reverseZones) {
+		if err := deleteOwnedPTRRecord(ctx, client.PDNS, reverseZones[ip], ip, name); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	tflog.Info(ctx, "Deleted PowerDNS host")
+	return nil
+}
+
+// resourcePDNSHostImport takes the hostname as import ID. The forward zone
+// and the reverse zones are looked up on the server, and only addresses whose
+// PTR record points back to the host are adopted.
+func resourcePDNSHostImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*ProviderClients)
+
+	tflog.Info(ctx, "Importing PowerDNS host", map[string]any{"id": d.Id()})
+
+	name := d.Id()
+	if _, errs := ValidateFQDN(name, "name"); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid import ID %q, expected a fully qualified hostname such as www.example.com.: %w", name, errs[0])
+	}
+
+	zones, err := client.PDNS.ListZones(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't list zones to import %s: %w", name, err)
+	}
+	zone, err := findZoneForName(zones, name)
+	if err != nil {
+		return nil, err
+	}
+
+	forward, err := listHostAddresses(ctx, client.PDNS, zone, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(forward) == 0 {
+		return nil, fmt.Errorf("host %s has no A or AAAA records to import", name)
+	}
+
+	reverseZones := make(map[string]string, len(forward))
+	for _, record := range forward {
+		reverseZone, err := findReverseZoneForIP(zones, record.Content)
+		if err != nil {
+			return nil, err
+		}
+		reverseZones[record.Content] = reverseZone
+	}
+
+	if err := d.Set("zone", zone); err != nil {
+		return nil, fmt.Errorf("error setting zone: %w", err)
+	}
+	if err := d.Set("reverse_zones", reverseZones); err != nil {
+		return nil, fmt.Errorf("error setting reverse_zones: %w", err)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourcePDNSHostCustomizeDiff infers the forward zone when it is not
+// configured, and marks reverse_zones as unknown whenever the addresses
+// change. A forward zone that does not exist yet, and the reverse zones, are
+// looked up at apply time, so a host can be created together with the zones
+// it lives in.
+func resourcePDNSHostCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || d.HasChange("ip_addresses") {
+		if err := d.SetNewComputed("reverse_zones"); err != nil {
+			return fmt.Errorf("error marking reverse_zones as computed: %w", err)
+		}
+	}
+
+	zoneConfigured := false
+	if rawConfig := d.GetRawConfig(); !rawConfig.IsNull() && rawConfig.IsKnown() {
+		zoneConfigured = !rawConfig.GetAttr("zone").IsNull()
+	}
+
+	if !d.NewValueKnown("name") || (zoneConfigured && !d.NewValueKnown("zone")) {
+		return nil
+	}
+
+	name := d.Get("name").(string)
+	zone := d.Get("zone").(string)
+
+	if !zoneConfigured && d.Id() == "" {
+		client := meta.(*ProviderClients)
+		zones, err := client.PDNS.ListZones(ctx)
+		if err != nil {
+			return fmt.Errorf("couldn't list zones to infer the zone of %s: %w", name, err)
+		}
+
+		zone, err = findZoneForName(zones, name)
+		if errors.Is(err, errNoZoneForName) {
+			// As for powerdns_record, the zone may be created in the same
+			// apply, so it is inferred again when the host is created.
+			tflog.Debug(ctx, "No zone found for PowerDNS host yet; inferring it on apply", map[string]any{"name": name})
+			return d.SetNewComputed("zone")
+		}
+		if err != nil {
+			return err
+		}
+		if err := d.SetNew("zone", zone); err != nil {
+			return fmt.Errorf("error setting inferred zone: %w", err)
+		}
+		tflog.Debug(ctx, "Inferred zone for PowerDNS host", map[string]any{"name": name, "zone": zone})
+	}
+
+	if !recordNameInZone(name, zone) {
+		return fmt.Errorf("host name %s is not inside zone %s", name, zone)
+	}
+
+	return nil
+}
+
+// hostForwardRRSets returns the A and AAAA RRsets of a host. A family without
+// addresses gets a DELETE, so dropping the last IPv6 address removes the AAAA
+// RRset.
+func hostForwardRRSets(name string, ttl int, ipAddresses []string) []ResourceRecordSet {
+	a := ResourceRecordSet{Name: name, Type: "A", ChangeType: "DELETE"}
+	aaaa := ResourceRecordSet{Name: name, Type: "AAAA", ChangeType: "DELETE"}
+
+	sorted := append([]string(nil), ipAddresses...)
+	sort.Strings(sorted)
+	for _, ip := range sorted {
+		rrSet := &aaaa
+		if net.ParseIP(ip).To4() != nil {
+			rrSet = &a
+		}
+		rrSet.ChangeType = "REPLACE"
+		rrSet.TTL = ttl
+		rrSet.Records = append(rrSet.Records, Record{Content: ip, TTL: ttl})
+	}
+
+	return []ResourceRecordSet{a, aaaa}
+}
+
+// listHostAddresses returns the A and AAAA records of name in zone.
+func listHostAddresses(ctx context.Context, client *PowerDNSClient, zone string, name string) ([]Record, error) {
+	var records []Record
+	for _, typ := range []string{"A", "AAAA"} {
+		rrRecords, err := client.ListRecordsInRRSet(ctx, zone, name, typ)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't fetch %s records of %s: %w", typ, name, err)
+		}
+		records = append(records, rrRecords...)
+	}
+	return records, nil
+}
+
+// listHostPTRRecords returns the PTR RRset ptrName of reverseZone. A reverse
+// zone that no longer exists holds no records, so its PTR records count as
+// gone rather than failing the read.
+func listHostPTRRecords(ctx context.Context, client *PowerDNSClient, reverseZone string, ptrName string) ([]Record, error) {
+	records, err := client.ListRecordsInRRSet(ctx, reverseZone, ptrName, "PTR")
+	if err == nil {
+		return records, nil
+	}
+	if exists, existsErr := client.ZoneExists(ctx, reverseZone); existsErr == nil && !exists {
+		tflog.Warn(ctx, "Reverse zone of host address not found", map[string]any{"reverse_zone": reverseZone, "ptr_name": ptrName})
+		return nil, nil
+	}
+	return nil, fmt.Errorf("couldn't fetch PTR record %s: %w", ptrName, err)
+}
+
+// checkHostForwardOwnership fails when name already has A or AAAA records,
+// which belong to something other than the host being created, such as a
+// powerdns_record resource.
+func checkHostForwardOwnership(name string, records []Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	existing := make([]string, 0, len(records))
+	for _, record := range records {
+		existing = append(existing, record.Type+" "+record.Content)
+	}
+	sort.Strings(existing)
+	return fmt.Errorf("%s already has A or AAAA records (%s); refusing to take them over, import the host instead", name, strings.Join(existing, ", "))
+}
+
+// ptrOwnedBy reports whether a PTR RRset exists and points only at hostname.
+func ptrOwnedBy(records []Record, hostname string) bool {
+	if len(records) == 0 {
+		return false
+	}
+	for _, record := range records {
+		if !strings.EqualFold(record.Content, hostname) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkPTROwnership fails when a PTR RRset exists and points at anything
+// other than hostname.
+func checkPTROwnership(ptrName string, records []Record, hostname string) error {
+	if len(records) == 0 || ptrOwnedBy(records, hostname) {
+		return nil
+	}
+
+	targets := make([]string, 0, len(records))
+	for _, record := range records {
+		targets = append(targets, record.Content)
+	}
+	return fmt.Errorf("PTR record %s already points to %s; refusing to take it over for %s", ptrName, strings.Join(targets, ", "), hostname)
+}
+
+// deleteOwnedPTRRecord removes the PTR record of ip from reverseZone if it
+// still points only at hostname. A PTR record that was changed to point
+// elsewhere is left alone.
+func deleteOwnedPTRRecord(ctx context.Context, client *PowerDNSClient, reverseZone string, ip string, hostname string) error {
+	ptrName, err := GetPTRRecordFQDN(ip, reverseZone)
+	if err != nil {
+		return fmt.Errorf("failed to determine PTR record name: %w", err)
+	}
+
+	records, err := listHostPTRRecords(ctx, client, reverseZone, ptrName)
+	if err != nil {
+		return err
+	}
+	if !ptrOwnedBy(records, hostname) {
+		if len(records) > 0 {
+			tflog.Warn(ctx, "PTR record no longer points at host; leaving it in place", map[string]any{
+				"ptr_name": ptrName,
+				"hostname": hostname,
+			})
+		}
+		return nil
+	}
+
+	if err := client.DeleteRecordSet(ctx, reverseZone, ptrName, "PTR"); err != nil {
+		return fmt.Errorf("error deleting PTR record %s: %w", ptrName, err)
+	}
+	return nil
+}
+
+// matchConfiguredIP returns the spelling of ip used in known, if an equal
+// address is there, so a differently written IPv6 address does not show up as
+// a change.
+func matchConfiguredIP(ip string, known map[string]string) string {
+	parsed := net.ParseIP(ip)
+	for candidate := range known {
+		if parsed != nil && parsed.Equal(net.ParseIP(candidate)) {
+			return candidate
+		}
+	}
+	return ip
+}
+
+func expandStringMap(values map[string]interface{}) map[string]string {
+	expanded := make(map[string]string, len(values))
+	for key, value := range values {
+		expanded[key] = value.(string)
+	}
+	return expanded
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,355 @@
+package powerdns
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHostForwardRRSets(t *testing.T) {
+	rrSets := hostForwardRRSets("web.example.com.", 300, []string{"192.0.2.20", "2001:db8::10", "192.0.2.10"})
+
+	assert.Equal(t, []ResourceRecordSet{
+		{
+			Name:       "web.example.com.",
+			Type:       "A",
+			ChangeType: "REPLACE",
+			TTL:        300,
+			Records: []Record{
+				{Content: "192.0.2.10", TTL: 300},
+				{Content: "192.0.2.20", TTL: 300},
+			},
+		},
+		{
+			Name:       "web.example.com.",
+			Type:       "AAAA",
+			ChangeType: "REPLACE",
+			TTL:        300,
+			Records:    []Record{{Content: "2001:db8::10", TTL: 300}},
+		},
+	}, rrSets)
+}
+
+func TestHostForwardRRSetsDeletesEmptyFamily(t *testing.T) {
+	rrSets := hostForwardRRSets("web.example.com.", 300, []string{"192.0.2.10"})
+	assert.Equal(t, "REPLACE", rrSets[0].ChangeType)
+	assert.Equal(t, ResourceRecordSet{Name: "web.example.com.", Type: "AAAA", ChangeType: "DELETE"}, rrSets[1])
+
+	for _, rrSet := range hostForwardRRSets("web.example.com.", 0, nil) {
+		assert.Equal(t, "DELETE", rrSet.ChangeType)
+		assert.Empty(t, rrSet.Records)
+	}
+}
+
+func TestCheckPTROwnership(t *testing.T) {
+	ptrName := "10.2.0.192.in-addr.arpa."
+
+	assert.NoError(t, checkPTROwnership(ptrName, nil, "web.example.com."))
+	assert.NoError(t, checkPTROwnership(ptrName, []Record{{Content: "WEB.example.com."}}, "web.example.com."))
+
+	err := checkPTROwnership(ptrName, []Record{{Content: "mail.example.com."}}, "web.example.com.")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "already points to mail.example.com.")
+	}
+
+	// A PTR RRset that also points elsewhere is not owned either.
+	assert.Error(t, checkPTROwnership(ptrName, []Record{{Content: "web.example.com."}, {Content: "mail.example.com."}}, "web.example.com."))
+}
+
+func TestPTROwnedBy(t *testing.T) {
+	assert.False(t, ptrOwnedBy(nil, "web.example.com."))
+	assert.True(t, ptrOwnedBy([]Record{{Content: "web.example.com."}}, "web.example.com."))
+	assert.False(t, ptrOwnedBy([]Record{{Content: "mail.example.com."}}, "web.example.com."))
+}
+
+func TestMatchConfiguredIP(t *testing.T) {
+	known := map[string]string{
+		"2001:DB8:0::10": "8.b.d.0.1.0.0.2.ip6.arpa.",
+		"192.0.2.10":     "2.0.192.in-addr.arpa.",
+	}
+
+	assert.Equal(t, "2001:DB8:0::10", matchConfiguredIP("2001:db8::10", known))
+	assert.Equal(t, "192.0.2.10", matchConfiguredIP("192.0.2.10", known))
+	assert.Equal(t, "192.0.2.99", matchConfiguredIP("192.0.2.99", known))
+}
+
+func TestResourcePDNSHostCreateKeepsIDWhenPTRFails(t *testing.T) {
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/servers/localhost/zones":
+			return jsonResponse(http.StatusOK, `[
+				{"id": "example.com.", "name": "example.com.", "kind": "Native"},
+				{"id": "2.0.192.in-addr.arpa.", "name": "2.0.192.in-addr.arpa.", "kind": "Native"}
+			]`), nil
+		case r.Method == http.MethodGet:
+			return jsonResponse(http.StatusOK, `{"name": "2.0.192.in-addr.arpa.", "rrsets": []}`), nil
+		case r.URL.Path == "/api/v1/servers/localhost/zones/example.com.":
+			return jsonResponse(http.StatusNoContent, ``), nil
+		default:
+			return jsonResponse(http.StatusUnprocessableEntity, `{"error": "PTR write failed"}`), nil
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, resourcePDNSHost().Schema, map[string]interface{}{
+		"name":         "www.example.com.",
+		"zone":         "example.com.",
+		"ip_addresses": []interface{}{"192.0.2.10"},
+		"ttl":          300,
+	})
+	diags := resourcePDNSHostCreate(context.Background(), d, &ProviderClients{PDNS: client})
+	if assert.True(t, diags.HasError()) {
+		assert.Contains(t, diags[0].Summary, "failed to create PTR records")
+	}
+	assert.Equal(t, "www.example.com.", d.Id())
+	assert.Equal(t, map[string]interface{}{"192.0.2.10": "2.0.192.in-addr.arpa."}, d.Get("reverse_zones"))
+}
+
+func TestResourcePDNSHostCreateRefusesExistingForwardRecords(t *testing.T) {
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodGet, r.Method, "nothing may be written")
+		switch r.URL.Path {
+		case "/api/v1/servers/localhost/zones":
+			return jsonResponse(http.StatusOK, `[
+				{"id": "example.com.", "name": "example.com.", "kind": "Native"},
+				{"id": "2.0.192.in-addr.arpa.", "name": "2.0.192.in-addr.arpa.", "kind": "Native"}
+			]`), nil
+		case "/api/v1/servers/localhost/zones/example.com.":
+			return jsonResponse(http.StatusOK, `{"name": "example.com.", "rrsets": [
+				{"name": "www.example.com.", "type": "A", "ttl": 300, "records": [{"content": "192.0.2.99"}]}
+			]}`), nil
+		default:
+			return jsonResponse(http.StatusOK, `{"name": "2.0.192.in-addr.arpa.", "rrsets": []}`), nil
+		}
+	})
+
+	// The zone is left empty, as when the plan could not infer it.
+	d := schema.TestResourceDataRaw(t, resourcePDNSHost().Schema, map[string]interface{}{
+		"name":         "www.example.com.",
+		"ip_addresses": []interface{}{"192.0.2.10"},
+		"ttl":          300,
+	})
+	diags := resourcePDNSHostCreate(context.Background(), d, &ProviderClients{PDNS: client})
+	if assert.True(t, diags.HasError()) {
+		assert.Equal(t, "www.example.com. already has A or AAAA records (A 192.0.2.99); refusing to take them over, import the host instead", diags[0].Summary)
+	}
+	assert.Equal(t, "example.com.", d.Get("zone"))
+	assert.Empty(t, d.Id())
+}
+
+func TestResourcePDNSHostWithDeletedReverseZone(t *testing.T) {
+	var deleted []string
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		switch {
+		case r.URL.Path == "/api/v1/servers/localhost/zones/example.com." && r.Method == http.MethodGet:
+			return jsonResponse(http.StatusOK, `{"name": "example.com.", "rrsets": [
+				{"name": "www.example.com.", "type": "A", "ttl": 300, "records": [{"content": "192.0.2.10"}]}
+			]}`), nil
+		case r.URL.Path == "/api/v1/servers/localhost/zones/example.com.":
+			deleted = append(deleted, r.URL.Path)
+			return jsonResponse(http.StatusNoContent, ``), nil
+		case r.Method == http.MethodGet:
+			return jsonResponse(http.StatusNotFound, `{"error": "Not Found"}`), nil
+		}
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		return jsonResponse(http.StatusUnprocessableEntity, `{"error": "unexpected"}`), nil
+	})
+	meta := &ProviderClients{PDNS: client}
+
+	d := schema.TestResourceDataRaw(t, resourcePDNSHost().Schema, map[string]interface{}{
+		"name":         "www.example.com.",
+		"zone":         "example.com.",
+		"ip_addresses": []interface{}{"192.0.2.10"},
+		"ttl":          300,
+	})
+	d.SetId("www.example.com.")
+	assert.NoError(t, d.Set("reverse_zones", map[string]interface{}{"192.0.2.10": "2.0.192.in-addr.arpa."}))
+
+	// The PTR record went with its zone, so the address is left out of
+	// state and shows up as a change.
+	diags := resourcePDNSHostRead(context.Background(), d, meta)
+	if assert.False(t, diags.HasError(), diags) {
+		assert.Empty(t, d.Get("ip_addresses").(*schema.Set).List())
+	}
+
+	assert.NoError(t, d.Set("reverse_zones", map[string]interface{}{"192.0.2.10": "2.0.192.in-addr.arpa."}))
+	diags = resourcePDNSHostDelete(context.Background(), d, meta)
+	assert.False(t, diags.HasError(), diags)
+	assert.Len(t, deleted, 1)
+}
+
+func TestResourcePDNSHostDefersMissingZone(t *testing.T) {
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusOK, `[{"id": "other.example.", "name": "other.example.", "kind": "Native"}]`), nil
+	})
+
+	r := resourcePDNSHost()
+	sm := schema.InternalMap(r.Schema)
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":         "www.example.com.",
+		"ip_addresses": []interface{}{"192.0.2.10"},
+		"ttl":          300,
+	})
+	diff, err := sm.Diff(context.Background(), nil, config, r.CustomizeDiff, &ProviderClients{PDNS: client}, true)
+	if assert.NoError(t, err) && assert.Contains(t, diff.Attributes, "zone") {
+		assert.True(t, diff.Attributes["zone"].NewComputed)
+	}
+}
+
+func TestAccPDNSHost_Basic(t *testing.T) {
+	resourceName := "powerdns_host.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSHostDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPDNSHostConfig_Basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPDNSHostPTR("203.0.113.10", "203.0.113.0/24", true),
+					testAccCheckPDNSHostPTR("2001:db8:aa::10", "2001:db8:aa::/48", true),
+					resource.TestCheckResourceAttr(resourceName, "zone", "host.sysa.xyz."),
+					resource.TestCheckResourceAttr(resourceName, "ip_addresses.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "reverse_zones.203.0.113.10", "113.0.203.in-addr.arpa."),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportStateId:     "web.host.sysa.xyz.",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccPDNSHostConfig_Updated,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPDNSHostPTR("203.0.113.11", "203.0.113.0/24", true),
+					testAccCheckPDNSHostPTR("203.0.113.10", "203.0.113.0/24", false),
+					testAccCheckPDNSHostPTR("2001:db8:aa::10", "2001:db8:aa::/48", false),
+					resource.TestCheckResourceAttr(resourceName, "ip_addresses.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "ttl", "600"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPDNSHost_PTRTakenByOtherName(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSHostDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccPDNSHostConfig_PTRTaken,
+				ExpectError: regexp.MustCompile(`already points to other\.host\.sysa\.xyz\.`),
+			},
+		},
+	})
+}
+
+// testAccCheckPDNSHostPTR checks whether the PTR record of ip in the reverse
+// zone of cidr points at the test host.
+func testAccCheckPDNSHostPTR(ip string, cidr string, exists bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		reverseZone, err := GetReverseZoneName(cidr)
+		if err != nil {
+			return err
+		}
+		ptrName, err := GetPTRRecordFQDN(ip, reverseZone)
+		if err != nil {
+			return err
+		}
+
+		client := testAccProvider.Meta().(*ProviderClients).PDNS
+		records, err := client.ListRecordsInRRSet(context.Background(), reverseZone, ptrName, "PTR")
+		if err != nil {
+			return fmt.Errorf("error fetching PTR record %s: %w", ptrName, err)
+		}
+
+		if owned := ptrOwnedBy(records, "web.host.sysa.xyz."); owned != exists {
+			return fmt.Errorf("PTR record %s: expected present=%t, got records %v", ptrName, exists, records)
+		}
+		return nil
+	}
+}
+
+func testAccCheckPDNSHostDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ProviderClients).PDNS
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "powerdns_host" {
+			continue
+		}
+
+		records, err := listHostAddresses(context.Background(), client, rs.Primary.Attributes["zone"], rs.Primary.ID)
+		if err == nil && len(records) > 0 {
+			return fmt.Errorf("host %s still has address records", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+const testAccPDNSHostConfig_Zones = `
+resource "powerdns_zone" "test" {
+  name = "host.sysa.xyz."
+  kind = "Native"
+}
+
+resource "powerdns_reverse_zone" "v4" {
+  cidr        = "203.0.113.0/24"
+  kind        = "Master"
+  nameservers = ["ns1.sysa.xyz."]
+}
+
+resource "powerdns_reverse_zone" "v6" {
+  cidr        = "2001:db8:aa::/48"
+  kind        = "Master"
+  nameservers = ["ns1.sysa.xyz."]
+}
+`
+
+const testAccPDNSHostConfig_Basic = testAccPDNSHostConfig_Zones + `
+resource "powerdns_host" "test" {
+  zone         = powerdns_zone.test.name
+  name         = "web.host.sysa.xyz."
+  ip_addresses = ["203.0.113.10", "2001:db8:aa::10"]
+  ttl          = 300
+
+  depends_on = [powerdns_reverse_zone.v4, powerdns_reverse_zone.v6]
+}
+`
+
+const testAccPDNSHostConfig_Updated = testAccPDNSHostConfig_Zones + `
+resource "powerdns_host" "test" {
+  zone         = powerdns_zone.test.name
+  name         = "web.host.sysa.xyz."
+  ip_addresses = ["203.0.113.11"]
+  ttl          = 600
+
+  depends_on = [powerdns_reverse_zone.v4, powerdns_reverse_zone.v6]
+}
+`
+
+const testAccPDNSHostConfig_PTRTaken = testAccPDNSHostConfig_Zones + `
+resource "powerdns_ptr_record" "other" {
+  ip_address   = "203.0.113.12"
+  hostname     = "other.host.sysa.xyz."
+  ttl          = 300
+  reverse_zone = powerdns_reverse_zone.v4.name
+}
+
+resource "powerdns_host" "test" {
+  zone         = powerdns_zone.test.name
+  name         = "web.host.sysa.xyz."
+  ip_addresses = ["203.0.113.12"]
+  ttl          = 300
+
+  depends_on = [powerdns_ptr_record.other, powerdns_reverse_zone.v6]
+}
+`
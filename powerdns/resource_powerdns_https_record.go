@@ -0,0 +1,104 @@
+package powerdns
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var httpsRecordType = structuredRecordType{
+	types: []string{"HTTPS", "SVCB"},
+	record: map[string]*schema.Schema{
+		"priority": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.IntBetween(0, 65535),
+			Description:  "The SvcPriority. 0 selects AliasMode, any other value ServiceMode.",
+		},
+		"target": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: ValidateFQDN,
+			Description:  "The TargetName. Must be a fully qualified domain name ending with a trailing dot, or \".\" for the owner name.",
+		},
+		"params": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "SvcParams keyed by parameter name, for example alpn = \"h2,h3\". Use an empty string for parameters without a value, such as no-default-alpn.",
+		},
+	},
+	build: buildSVCBContent,
+	parse: parseSVCBContent,
+}
+
+func resourcePDNSHTTPSRecord() *schema.Resource {
+	return resourcePDNSStructuredRecord(httpsRecordType)
+}
+
+func buildSVCBContent(record map[string]interface{}) (string, error) {
+	priority := record["priority"].(int)
+	params, _ := record["params"].(map[string]interface{})
+
+	if priority == 0 && len(params) > 0 {
+		return "", fmt.Errorf("records with priority 0 (AliasMode) must not have params")
+	}
+
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := []string{strconv.Itoa(priority), record["target"].(string)}
+	for _, key := range keys {
+		value := params[key].(string)
+		switch {
+		case value == "":
+			parts = append(parts, key)
+		case strings.ContainsAny(value, " \t\"\\"):
+			parts = append(parts, key+"="+quoteTXTCharacterString(value))
+		default:
+			parts = append(parts, key+"="+value)
+		}
+	}
+
+	return strings.Join(parts, " "), nil
+}
+
+func parseSVCBContent(content string) (map[string]interface{}, error) {
+	fields, err := splitRecordContentFields(content)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("expected at least 2 fields, got %d", len(fields))
+	}
+
+	priority, err := strconv.ParseUint(fields[0], 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid priority %q: %w", fields[0], err)
+	}
+
+	params := make(map[string]interface{}, len(fields)-2)
+	for _, field := range fields[2:] {
+		key, value, _ := strings.Cut(field, "=")
+		if strings.HasPrefix(value, "\"") {
+			value, err = decodeTXTContent(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for %s: %w", key, err)
+			}
+		}
+		params[key] = value
+	}
+
+	return map[string]interface{}{
+		"priority": int(priority),
+		"target":   fields[1],
+		"params":   params,
+	}, nil
+}
@@ -0,0 +1,124 @@
+package powerdns
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildSVCBContent(t *testing.T) {
+	content, err := buildSVCBContent(map[string]interface{}{
+		"priority": 1,
+		"target":   ".",
+		"params":   map[string]interface{}{"port": "8443", "alpn": "h2,h3"},
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "1 . alpn=h2,h3 port=8443", content)
+	}
+}
+
+func TestBuildSVCBContentAliasModeRejectsParams(t *testing.T) {
+	_, err := buildSVCBContent(map[string]interface{}{
+		"priority": 0,
+		"target":   "svc.example.com.",
+		"params":   map[string]interface{}{"alpn": "h2"},
+	})
+	assert.Error(t, err)
+}
+
+func TestParseSVCBContentQuotedValue(t *testing.T) {
+	parsed, err := parseSVCBContent(`1 svc.example.com. alpn="h2,h3" ipv4hint=192.0.2.1`)
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]interface{}{
+			"priority": 1,
+			"target":   "svc.example.com.",
+			"params":   map[string]interface{}{"alpn": "h2,h3", "ipv4hint": "192.0.2.1"},
+		}, parsed)
+	}
+}
+
+func TestAccPDNSHTTPSRecord_Basic(t *testing.T) {
+	resourceName := "powerdns_https_record.test"
+	resourceID := `{"zone":"rec-https-typed.sysa.xyz.","id":"rec-https-typed.sysa.xyz.:::HTTPS"}`
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSRecordDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testPDNSHTTPSRecordConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPDNSRecordExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "type", "HTTPS"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "record.*", map[string]string{
+						"priority":    "1",
+						"target":      ".",
+						"params.alpn": "h2,h3",
+					}),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportStateId:     resourceID,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccPDNSHTTPSRecord_AliasModeWithParams(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testPDNSHTTPSRecordConfigAliasModeWithParams,
+				ExpectError: regexp.MustCompile("must not have params"),
+			},
+		},
+	})
+}
+
+const testPDNSHTTPSRecordConfig = `
+resource "powerdns_zone" "test-zone" {
+	name = "rec-https-typed.sysa.xyz."
+	kind = "Native"
+}
+
+resource "powerdns_https_record" "test" {
+	zone = powerdns_zone.test-zone.name
+	name = "rec-https-typed.sysa.xyz."
+	ttl = 60
+
+	record {
+		priority = 1
+		target = "."
+		params = {
+			alpn = "h2,h3"
+		}
+	}
+}`
+
+const testPDNSHTTPSRecordConfigAliasModeWithParams = `
+resource "powerdns_zone" "test-zone" {
+	name = "rec-https-alias.sysa.xyz."
+	kind = "Native"
+}
+
+resource "powerdns_https_record" "test" {
+	zone = powerdns_zone.test-zone.name
+	name = "rec-https-alias.sysa.xyz."
+	ttl = 60
+
+	record {
+		priority = 0
+		target = "svc.sysa.xyz."
+		params = {
+			alpn = "h2"
+		}
+	}
+}`
@@ -0,0 +1,77 @@
+package powerdns
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var mxRecordType = structuredRecordType{
+	types: []string{"MX"},
+	record: map[string]*schema.Schema{
+		"preference": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.IntBetween(0, 65535),
+			Description:  "The preference of the mail exchanger. Lower values are preferred.",
+		},
+		"exchange": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: ValidateFQDN,
+			Description:  "The mail exchanger host name. Must be a fully qualified domain name ending with a trailing dot, or \".\" for a null MX record.",
+		},
+	},
+	build:    buildMXContent,
+	parse:    parseMXContent,
+	validate: validateNullMX,
+}
+
+func resourcePDNSMXRecord() *schema.Resource {
+	return resourcePDNSStructuredRecord(mxRecordType)
+}
+
+func buildMXContent(record map[string]interface{}) (string, error) {
+	preference := record["preference"].(int)
+	exchange := record["exchange"].(string)
+	if exchange == "." && preference != 0 {
+		return "", fmt.Errorf("a null MX record (exchange \".\") must have preference 0, got %d", preference)
+	}
+	return fmt.Sprintf("%d %s", preference, exchange), nil
+}
+
+// validateNullMX rejects a null MX record that is not the only record of its
+// RRset (RFC 7505, section 3).
+func validateNullMX(records []map[string]interface{}) error {
+	if len(records) < 2 {
+		return nil
+	}
+	for _, record := range records {
+		if record["exchange"].(string) == "." {
+			return fmt.Errorf("a null MX record (exchange \".\") must be the only record in the RRset")
+		}
+	}
+	return nil
+}
+
+func parseMXContent(content string) (map[string]interface{}, error) {
+	fields, err := splitRecordContentFields(content)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) != 2 {
+		return nil, fmt.Errorf("expected 2 fields, got %d", len(fields))
+	}
+
+	preference, err := strconv.ParseUint(fields[0], 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid preference %q: %w", fields[0], err)
+	}
+
+	return map[string]interface{}{
+		"preference": int(preference),
+		"exchange":   fields[1],
+	}, nil
+}
@@ -0,0 +1,149 @@
+package powerdns
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildMXContent(t *testing.T) {
+	content, err := buildMXContent(map[string]interface{}{"preference": 10, "exchange": "mail.example.com."})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "10 mail.example.com.", content)
+	}
+}
+
+func TestBuildMXContentNullMX(t *testing.T) {
+	content, err := buildMXContent(map[string]interface{}{"preference": 0, "exchange": "."})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "0 .", content)
+	}
+
+	_, err = buildMXContent(map[string]interface{}{"preference": 10, "exchange": "."})
+	assert.ErrorContains(t, err, "must have preference 0")
+}
+
+func TestValidateNullMX(t *testing.T) {
+	assert.NoError(t, validateNullMX([]map[string]interface{}{{"preference": 0, "exchange": "."}}))
+	assert.NoError(t, validateNullMX([]map[string]interface{}{
+		{"preference": 10, "exchange": "mail1.example.com."},
+		{"preference": 20, "exchange": "mail2.example.com."},
+	}))
+	assert.ErrorContains(t, validateNullMX([]map[string]interface{}{
+		{"preference": 0, "exchange": "."},
+		{"preference": 10, "exchange": "mail.example.com."},
+	}), "must be the only record")
+}
+
+func TestParseMXContentInvalid(t *testing.T) {
+	for _, content := range []string{"mail.example.com.", "ten mail.example.com.", "70000 mail.example.com."} {
+		_, err := parseMXContent(content)
+		assert.Error(t, err, content)
+	}
+}
+
+func TestAccPDNSMXRecord_Basic(t *testing.T) {
+	resourceName := "powerdns_mx_record.test"
+	resourceID := `{"zone":"rec-mx-typed.sysa.xyz.","id":"rec-mx-typed.sysa.xyz.:::MX"}`
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSRecordDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testPDNSMXRecordConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPDNSRecordExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "record.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "record.*", map[string]string{
+						"preference": "10",
+						"exchange":   "mail1.sysa.xyz.",
+					}),
+				),
+			},
+			{
+				Config: testPDNSMXRecordConfigUpdated,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "ttl", "120"),
+					resource.TestCheckResourceAttr(resourceName, "record.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "record.*", map[string]string{
+						"preference": "5",
+						"exchange":   "mail3.sysa.xyz.",
+					}),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportStateId:     resourceID,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccPDNSMXRecord_NullMXWithPreference(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSRecordDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "powerdns_mx_record" "test" {
+	zone = "sysa.xyz."
+	name = "null-mx.sysa.xyz."
+	ttl  = 60
+	record {
+		preference = 10
+		exchange   = "."
+	}
+}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("must have preference 0"),
+			},
+		},
+	})
+}
+
+const testPDNSMXRecordConfig = `
+resource "powerdns_zone" "test-zone" {
+	name = "rec-mx-typed.sysa.xyz."
+	kind = "Native"
+}
+
+resource "powerdns_mx_record" "test" {
+	zone = powerdns_zone.test-zone.name
+	name = "rec-mx-typed.sysa.xyz."
+	ttl = 60
+
+	record {
+		preference = 10
+		exchange = "mail1.sysa.xyz."
+	}
+
+	record {
+		preference = 20
+		exchange = "mail2.sysa.xyz."
+	}
+}`
+
+const testPDNSMXRecordConfigUpdated = `
+resource "powerdns_zone" "test-zone" {
+	name = "rec-mx-typed.sysa.xyz."
+	kind = "Native"
+}
+
+resource "powerdns_mx_record" "test" {
+	zone = powerdns_zone.test-zone.name
+	name = "rec-mx-typed.sysa.xyz."
+	ttl = 120
+
+	record {
+		preference = 5
+		exchange = "mail3.sysa.xyz."
+	}
+}`
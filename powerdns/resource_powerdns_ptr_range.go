@@ -0,0 +1,563 @@
+package powerdns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// ptrRangeMaxAddresses caps the size of a powerdns_ptr_range, a /16 for
+	// IPv4 or a /112 for IPv6.
+	ptrRangeMaxAddresses = 1 << 16
+	// ptrRangeBatchSize is the number of RRsets sent in a single PATCH.
+	ptrRangeBatchSize = 1000
+)
+
+// resourcePDNSPTRRange manages generic PTR records for every address in a
+// block, such as "host-10-0-1-5.dyn.example.com." for DHCP space. The range is
+// written with one PATCH per reverse zone and batch, and read back with one
+// zone read per reverse zone, instead of one request per address.
+func resourcePDNSPTRRange() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePDNSPTRRangeCreate,
+		ReadContext:   resourcePDNSPTRRangeRead,
+		UpdateContext: resourcePDNSPTRRangeUpdate,
+		DeleteContext: resourcePDNSPTRRangeDelete,
+
+		CustomizeDiff: resourcePDNSPTRRangeCustomizeDiff,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcePDNSPTRRangeImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cidr": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validatePTRRangeCIDR,
+				Description:  "The IPv4 or IPv6 block to create PTR records for, at most 65536 addresses (e.g., '10.0.0.0/22').",
+			},
+			"hostname_template": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The hostname each address points to, with placeholders for parts of the address: {ip}, and {a}, {b}, {c}, {d} for the octets of an IPv4 address. For example 'host-{ip}.dyn.example.com.'.",
+			},
+			"ttl": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The TTL of the PTR records.",
+			},
+			"exclude": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.Any(validation.IsIPAddress, validation.IsCIDR),
+				},
+				Description: "Addresses or CIDR blocks inside cidr that get no generic PTR record, for example because they are managed with powerdns_ptr_record.",
+			},
+			"reverse_zones": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The reverse zones holding the PTR records of the range.",
+			},
+			"in_sync": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether every PTR record of the range exists and points to its generated hostname. When it does not, the next plan updates the range to write the records again.",
+			},
+		},
+	}
+}
+
+// validatePTRRangeCIDR validates a block that is small enough for a PTR range.
+func validatePTRRangeCIDR(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	_, block, err := net.ParseCIDR(value)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a valid CIDR, got: %s", k, value))
+		return
+	}
+	ones, bits := block.Mask.Size()
+	if bits-ones > 16 {
+		errors = append(errors, fmt.Errorf("%q may cover at most %d addresses (an IPv4 /16 or an IPv6 /112), got: %s", k, ptrRangeMaxAddresses, value))
+	}
+	return
+}
+
+var ptrTemplatePlaceholder = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// expandPTRHostnameTemplate fills in the placeholders of template for ip.
+// {ip} is the address with dots or colons replaced by dashes; IPv6 addresses
+// are written out in full so that every address gives a distinct, valid label.
+// {a} to {d} are the octets of an IPv4 address.
+func expandPTRHostnameTemplate(template string, ip net.IP) (string, error) {
+	v4 := ip.To4()
+
+	var expandErr error
+	hostname := ptrTemplatePlaceholder.ReplaceAllStringFunc(template, func(match string) string {
+		placeholder := strings.ToLower(match[1 : len(match)-1])
+		switch {
+		case placeholder == "ip" && v4 != nil:
+			return strings.ReplaceAll(v4.String(), ".", "-")
+		case placeholder == "ip":
+			groups := make([]string, 8)
+			for i := range groups {
+				groups[i] = fmt.Sprintf("%02x%02x", ip[2*i], ip[2*i+1])
+			}
+			return strings.Join(groups, "-")
+		case len(placeholder) == 1 && placeholder[0] >= 'a' && placeholder[0] <= 'd':
+			if v4 == nil {
+				expandErr = fmt.Errorf("placeholder %s is only supported for IPv4 addresses", match)
+				return match
+			}
+			return fmt.Sprintf("%d", v4[placeholder[0]-'a'])
+		default:
+			expandErr = fmt.Errorf("unknown placeholder %s in hostname template, expected {ip}, {a}, {b}, {c} or {d}", match)
+			return match
+		}
+	})
+	if expandErr != nil {
+		return "", expandErr
+	}
+
+	if _, errs := ValidateFQDN(hostname, "hostname_template"); len(errs) > 0 {
+		return "", errs[0]
+	}
+	return hostname, nil
+}
+
+// ptrRangeAddresses returns the addresses of the block in cidr that are not
+// excluded.
+func ptrRangeAddresses(cidr string, exclude []string) ([]net.IP, error) {
+	_, block, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cidr: %w", err)
+	}
+	excluded, err := parseIPExclusions(exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	ones, bits := block.Mask.Size()
+	size := 1 << (bits - ones)
+	addresses := make([]net.IP, 0, size)
+	ip := append(net.IP(nil), block.IP...)
+	for i := 0; i < size; i++ {
+		if ipExclusion(ip, excluded) == nil {
+			addresses = append(addresses, ip)
+		}
+		ip = nextIP(ip)
+	}
+	return addresses, nil
+}
+
+// ptrRangeRecords maps the PTR record names of a range to their hostnames,
+// grouped by reverse zone.
+type ptrRangeRecords map[string]map[string]string
+
+// newPTRRangeRecords computes the PTR records of a range, placing each one in
+// the most specific reverse zone in zones that covers its address. With
+// skipMissing, addresses without a reverse zone are left out instead of
+// failing, and their number is returned.
+func newPTRRangeRecords(zones []ZoneInfo, addresses []net.IP, template string, skipMissing bool) (ptrRangeRecords, int, error) {
+	records := ptrRangeRecords{}
+	skipped := 0
+	for _, ip := range addresses {
+		reverseZone, err := findReverseZoneForIP(zones, ip.String())
+		if skipMissing && errors.Is(err, errNoZoneForName) {
+			skipped++
+			continue
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		ptrName, err := GetPTRRecordFQDN(ip.String(), reverseZone)
+		if err != nil {
+			return nil, 0, err
+		}
+		hostname, err := expandPTRHostnameTemplate(template, ip)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if records[reverseZone] == nil {
+			records[reverseZone] = map[string]string{}
+		}
+		records[reverseZone][ptrName] = hostname
+	}
+	return records, skipped, nil
+}
+
+// reverseZones returns the reverse zones of the range, sorted by name.
+func (r ptrRangeRecords) reverseZones() []string {
+	return sortedKeys(r)
+}
+
+// rrSetBatches returns the changes for one reverse zone, sorted by owner name
+// and split into batches of at most ptrRangeBatchSize RRsets. With changeType
+// DELETE the RRsets carry no records.
+func (r ptrRangeRecords) rrSetBatches(reverseZone string, changeType string, ttl int) [][]ResourceRecordSet {
+	var batches [][]ResourceRecordSet
+	var batch []ResourceRecordSet
+	for _, ptrName := range sortedKeys(r[reverseZone]) {
+		rrSet := ResourceRecordSet{Name: ptrName, Type: "PTR", ChangeType: changeType}
+		if changeType == "REPLACE" {
+			rrSet.TTL = ttl
+			rrSet.Records = []Record{{Content: r[reverseZone][ptrName], TTL: ttl}}
+		}
+
+		batch = append(batch, rrSet)
+		if len(batch) == ptrRangeBatchSize {
+			batches = append(batches, batch)
+			batch = nil
+		}
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+// loadPTRRange lists the zones on the server and computes the PTR records of
+// the range configured in d. skipMissing is passed on to newPTRRangeRecords.
+func loadPTRRange(ctx context.Context, client *PowerDNSClient, d *schema.ResourceData, skipMissing bool) (ptrRangeRecords, int, error) {
+	addresses, err := ptrRangeAddresses(d.Get("cidr").(string), expandStringSet(d.Get("exclude").(*schema.Set)))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	zones, err := client.ListZones(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("couldn't list zones to find the reverse zones of %s: %w", d.Get("cidr").(string), err)
+	}
+
+	return newPTRRangeRecords(zones, addresses, d.Get("hostname_template").(string), skipMissing)
+}
+
+func resourcePDNSPTRRangeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourcePDNSPTRRangeUpsert(ctx, d, meta)
+}
+
+func resourcePDNSPTRRangeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourcePDNSPTRRangeUpsert(ctx, d, meta)
+}
+
+// resourcePDNSPTRRangeUpsert writes the PTR records of the range. Existing PTR
+// records are checked first, so the range never takes over a PTR record that
+// points to a name it did not generate, such as one of powerdns_ptr_record or
+// powerdns_host.
+func resourcePDNSPTRRangeUpsert(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderClients)
+
+	cidr := d.Get("cidr").(string)
+	ttl := d.Get("ttl").(int)
+	tflog.SetField(ctx, "cidr", cidr)
+	tflog.Debug(ctx, "Creating PTR range")
+
+	addresses, err := ptrRangeAddresses(cidr, expandStringSet(d.Get("exclude").(*schema.Set)))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	zones, err := client.PDNS.ListZones(ctx)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't list zones to find the reverse zones of %s: %w", cidr, err))
+	}
+	records, _, err := newPTRRangeRecords(zones, addresses, d.Get("hostname_template").(string), false)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Records generated by the previous template and exclude still belong
+	// to the range and may be replaced.
+	var previous ptrRangeRecords
+	if d.Id() != "" {
+		oldTemplate, _ := d.GetChange("hostname_template")
+		oldExclude, _ := d.GetChange("exclude")
+		oldAddresses, err := ptrRangeAddresses(cidr, expandStringSet(oldExclude.(*schema.Set)))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if previous, _, err = newPTRRangeRecords(zones, oldAddresses, oldTemplate.(string), true); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	for _, reverseZone := range records.reverseZones() {
+		if err := checkPTRRangeOwnership(ctx, client.PDNS, reverseZone, records, previous); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	for _, reverseZone := range records.reverseZones() {
+		for _, batch := range records.rrSetBatches(reverseZone, "REPLACE", ttl) {
+			if err := client.PDNS.PatchRecordSets(ctx, reverseZone, batch); err != nil {
+				return diag.FromErr(fmt.Errorf("failed to create PTR records of %s in zone %s: %w", cidr, reverseZone, err))
+			}
+		}
+	}
+
+	// Addresses that were excluded since the last apply lose the generic
+	// PTR record they had.
+	if d.Id() != "" && d.HasChange("exclude") {
+		oldExclude, _ := d.GetChange("exclude")
+		oldAddresses, err := ptrRangeAddresses(cidr, expandStringSet(oldExclude.(*schema.Set)))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		oldTemplate, _ := d.GetChange("hostname_template")
+		if err := deletePTRRangeRecords(ctx, client.PDNS, oldAddresses, oldTemplate.(string), records); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	_, block, _ := net.ParseCIDR(cidr)
+	d.SetId(block.String())
+	tflog.Info(ctx, "Created PTR range", map[string]any{"id": d.Id()})
+
+	return resourcePDNSPTRRangeRead(ctx, d, meta)
+}
+
+func resourcePDNSPTRRangeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderClients)
+
+	cidr := d.Get("cidr").(string)
+	tflog.SetField(ctx, "cidr", cidr)
+	tflog.Debug(ctx, "Reading PTR range")
+
+	// The records of an address whose reverse zone has been deleted are gone
+	// with it, so they count as missing.
+	records, missing, err := loadPTRRange(ctx, client.PDNS, d, true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// A record whose TTL differs from the configured one is reported as the
+	// TTL of the range, so drift in any record shows up in the plan.
+	expected, found, ttl := missing, 0, d.Get("ttl").(int)
+	for _, reverseZone := range records.reverseZones() {
+		live, err := client.PDNS.ListRecords(ctx, reverseZone)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("couldn't fetch records of zone %s: %w", reverseZone, err))
+		}
+
+		expected += len(records[reverseZone])
+		for _, record := range live {
+			if !strings.EqualFold(record.Type, "PTR") {
+				continue
+			}
+			hostname, ok := records[reverseZone][strings.ToLower(record.Name)]
+			if ok && strings.EqualFold(record.Content, hostname) {
+				found++
+				if record.TTL != d.Get("ttl").(int) {
+					ttl = record.TTL
+				}
+			}
+		}
+	}
+
+	if found == 0 {
+		tflog.Warn(ctx, "PTR range has no records left; removing from state")
+		d.SetId("")
+		return nil
+	}
+
+	if found != expected {
+		tflog.Warn(ctx, "PTR range is incomplete", map[string]any{
+			"found":    found,
+			"expected": expected,
+		})
+	}
+	if err := d.Set("in_sync", found == expected); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting in_sync: %w", err))
+	}
+
+	if err := d.Set("ttl", ttl); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting ttl: %w", err))
+	}
+	if err := d.Set("reverse_zones", records.reverseZones()); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting reverse_zones: %w", err))
+	}
+
+	return nil
+}
+
+func resourcePDNSPTRRangeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderClients)
+
+	cidr := d.Get("cidr").(string)
+	tflog.SetField(ctx, "cidr", cidr)
+	tflog.Debug(ctx, "Deleting PTR range")
+
+	addresses, err := ptrRangeAddresses(cidr, expandStringSet(d.Get("exclude").(*schema.Set)))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := deletePTRRangeRecords(ctx, client.PDNS, addresses, d.Get("hostname_template").(string), nil); err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Info(ctx, "Deleted PTR range")
+	return nil
+}
+
+// checkPTRRangeOwnership fails when a PTR record of the range in reverseZone
+// points to anything other than its generated hostname, or the one previous
+// generated for it.
+func checkPTRRangeOwnership(ctx context.Context, client *PowerDNSClient, reverseZone string, records ptrRangeRecords, previous ptrRangeRecords) error {
+	live, err := client.ListRecords(ctx, reverseZone)
+	if err != nil {
+		return fmt.Errorf("couldn't fetch records of zone %s: %w", reverseZone, err)
+	}
+
+	conflicts := map[string]bool{}
+	for _, record := range live {
+		name := strings.ToLower(record.Name)
+		hostname, ok := records[reverseZone][name]
+		if !ok || !strings.EqualFold(record.Type, "PTR") || strings.EqualFold(record.Content, hostname) {
+			continue
+		}
+		if old, ok := previous[reverseZone][name]; ok && strings.EqualFold(record.Content, old) {
+			continue
+		}
+		conflicts[record.Name+" -> "+record.Content] = true
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	examples := sortedKeys(conflicts)
+	if len(examples) > 5 {
+		examples = append(examples[:5:5], "...")
+	}
+	return fmt.Errorf("%d PTR records in zone %s already point to other names (%s); refusing to take them over, add their addresses to exclude",
+		len(conflicts), reverseZone, strings.Join(examples, ", "))
+}
+
+// deletePTRRangeRecords deletes the PTR records that template gives for
+// addresses, except those still part of keep. Only records that still point to
+// the generated hostname are deleted, so PTR records written by anything else
+// in the meantime are left alone.
+func deletePTRRangeRecords(ctx context.Context, client *PowerDNSClient, addresses []net.IP, template string, keep ptrRangeRecords) error {
+	zones, err := client.ListZones(ctx)
+	if err != nil {
+		return fmt.Errorf("couldn't list zones to find the reverse zones of the PTR range: %w", err)
+	}
+	// Addresses whose reverse zone no longer exists have no records left to
+	// delete.
+	records, _, err := newPTRRangeRecords(zones, addresses, template, true)
+	if err != nil {
+		return err
+	}
+
+	for _, reverseZone := range records.reverseZones() {
+		live, err := client.ListRecords(ctx, reverseZone)
+		if err != nil {
+			return fmt.Errorf("couldn't fetch records of zone %s: %w", reverseZone, err)
+		}
+
+		owned := ptrRangeRecords{reverseZone: {}}
+		for _, record := range live {
+			name := strings.ToLower(record.Name)
+			hostname, ok := records[reverseZone][name]
+			if !ok || !strings.EqualFold(record.Type, "PTR") || !strings.EqualFold(record.Content, hostname) {
+				continue
+			}
+			if _, kept := keep[reverseZone][name]; kept {
+				continue
+			}
+			owned[reverseZone][name] = hostname
+		}
+
+		for _, batch := range owned.rrSetBatches(reverseZone, "DELETE", 0) {
+			if err := client.PatchRecordSets(ctx, reverseZone, batch); err != nil {
+				return fmt.Errorf("error deleting PTR records in zone %s: %w", reverseZone, err)
+			}
+		}
+	}
+	return nil
+}
+
+// resourcePDNSPTRRangeCustomizeDiff plans an update for a range whose PTR
+// records drifted, and checks at plan time that the hostname template gives a
+// valid name for the addresses of the range.
+func resourcePDNSPTRRangeCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		if err := d.SetNewComputed("reverse_zones"); err != nil {
+			return fmt.Errorf("error marking reverse_zones as computed: %w", err)
+		}
+	} else if !d.Get("in_sync").(bool) {
+		if err := d.SetNew("in_sync", true); err != nil {
+			return fmt.Errorf("error setting in_sync: %w", err)
+		}
+	}
+
+	if !d.NewValueKnown("cidr") || !d.NewValueKnown("hostname_template") {
+		return nil
+	}
+
+	_, block, err := net.ParseCIDR(d.Get("cidr").(string))
+	if err != nil {
+		return nil
+	}
+	template := d.Get("hostname_template").(string)
+	for _, ip := range []net.IP{block.IP, lastIP(block)} {
+		if _, err := expandPTRHostnameTemplate(template, ip); err != nil {
+			return fmt.Errorf("invalid hostname_template: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// resourcePDNSPTRRangeImport accepts cidr:::hostname_template, such as
+// 10.0.0.0/22:::host-{ip}.dyn.example.com. exclude cannot be derived from the
+// records and starts out empty.
+func resourcePDNSPTRRangeImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	tflog.Info(ctx, "Importing PTR range", map[string]any{"id": d.Id()})
+
+	cidr, template, err := parsePTRRangeImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.Set("cidr", cidr); err != nil {
+		return nil, fmt.Errorf("error setting cidr: %w", err)
+	}
+	if err := d.Set("hostname_template", template); err != nil {
+		return nil, fmt.Errorf("error setting hostname_template: %w", err)
+	}
+	d.SetId(cidr)
+	return []*schema.ResourceData{d}, nil
+}
+
+// parsePTRRangeImportID splits a PTR range import ID into the canonical block
+// and the hostname template. IPv6 blocks contain colons, but hostnames do not,
+// so the ID is split at the last separator.
+func parsePTRRangeImportID(importID string) (string, string, error) {
+	i := strings.LastIndex(importID, idSeparator)
+	if i < 0 {
+		return "", "", fmt.Errorf("invalid import ID %q, expected cidr%shostname_template", importID, idSeparator)
+	}
+	cidr, template := importID[:i], importID[i+len(idSeparator):]
+
+	if _, errs := validatePTRRangeCIDR(cidr, "cidr"); len(errs) > 0 {
+		return "", "", fmt.Errorf("invalid import ID %q: %w", importID, errs[0])
+	}
+	_, block, _ := net.ParseCIDR(cidr)
+	if _, err := expandPTRHostnameTemplate(template, block.IP); err != nil {
+		return "", "", fmt.Errorf("invalid import ID %q: %w", importID, err)
+	}
+	return block.String(), template, nil
+}
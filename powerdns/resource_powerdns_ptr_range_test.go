@@ -0,0 +1,376 @@
+package powerdns
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandPTRHostnameTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		ip       string
+		expected string
+	}{
+		{
+			name:     "IPv4 address",
+			template: "host-{ip}.dyn.example.com.",
+			ip:       "10.0.1.5",
+			expected: "host-10-0-1-5.dyn.example.com.",
+		},
+		{
+			name:     "IPv4 octets",
+			template: "{d}.{c}.pool.example.com.",
+			ip:       "10.0.1.5",
+			expected: "5.1.pool.example.com.",
+		},
+		{
+			name:     "IPv6 address is written out in full",
+			template: "v6-{IP}.example.com.",
+			ip:       "2001:db8::5",
+			expected: "v6-2001-0db8-0000-0000-0000-0000-0000-0005.example.com.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hostname, err := expandPTRHostnameTemplate(tt.template, net.ParseIP(tt.ip))
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.expected, hostname)
+			}
+		})
+	}
+}
+
+func TestExpandPTRHostnameTemplateInvalid(t *testing.T) {
+	tests := []struct {
+		template string
+		ip       string
+	}{
+		{template: "host-{ip}.example.com", ip: "10.0.1.5"},
+		{template: "host-{host}.example.com.", ip: "10.0.1.5"},
+		{template: "host-{d}.example.com.", ip: "2001:db8::5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			_, err := expandPTRHostnameTemplate(tt.template, net.ParseIP(tt.ip))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestValidatePTRRangeCIDR(t *testing.T) {
+	for _, cidr := range []string{"10.0.0.0/22", "10.0.0.0/16", "2001:db8::/112", "10.0.0.1/32"} {
+		_, errs := validatePTRRangeCIDR(cidr, "cidr")
+		assert.Empty(t, errs, cidr)
+	}
+	for _, cidr := range []string{"10.0.0.0/15", "2001:db8::/64", "10.0.0.1"} {
+		_, errs := validatePTRRangeCIDR(cidr, "cidr")
+		assert.NotEmpty(t, errs, cidr)
+	}
+}
+
+func TestPTRRangeAddresses(t *testing.T) {
+	addresses, err := ptrRangeAddresses("10.0.1.0/29", []string{"10.0.1.0", "10.0.1.6/31"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var got []string
+	for _, ip := range addresses {
+		got = append(got, ip.String())
+	}
+	assert.Equal(t, []string{"10.0.1.1", "10.0.1.2", "10.0.1.3", "10.0.1.4", "10.0.1.5"}, got)
+}
+
+func TestNewPTRRangeRecords(t *testing.T) {
+	zones := []ZoneInfo{
+		{Name: "0.10.in-addr.arpa."},
+		{Name: "1.0.10.in-addr.arpa."},
+	}
+	addresses, err := ptrRangeAddresses("10.0.0.254/31", nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	addresses = append(addresses, net.ParseIP("10.0.1.1").To4())
+
+	records, skipped, err := newPTRRangeRecords(zones, addresses, "host-{ip}.dyn.example.com.", false)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Zero(t, skipped)
+
+	assert.Equal(t, ptrRangeRecords{
+		"0.10.in-addr.arpa.": {
+			"254.0.0.10.in-addr.arpa.": "host-10-0-0-254.dyn.example.com.",
+			"255.0.0.10.in-addr.arpa.": "host-10-0-0-255.dyn.example.com.",
+		},
+		"1.0.10.in-addr.arpa.": {
+			"1.1.0.10.in-addr.arpa.": "host-10-0-1-1.dyn.example.com.",
+		},
+	}, records)
+	assert.Equal(t, []string{"0.10.in-addr.arpa.", "1.0.10.in-addr.arpa."}, records.reverseZones())
+
+	_, _, err = newPTRRangeRecords([]ZoneInfo{{Name: "example.com."}}, addresses, "host-{ip}.dyn.example.com.", false)
+	assert.Error(t, err)
+
+	// A deleted reverse zone only drops the addresses it covered.
+	records, skipped, err = newPTRRangeRecords(zones[1:], addresses, "host-{ip}.dyn.example.com.", true)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 2, skipped)
+		assert.Equal(t, []string{"1.0.10.in-addr.arpa."}, records.reverseZones())
+	}
+}
+
+func TestPTRRangeWithDeletedReverseZone(t *testing.T) {
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/v1/servers/localhost/zones", r.URL.Path)
+		return jsonResponse(http.StatusOK, `[{"id": "example.com.", "name": "example.com.", "kind": "Native"}]`), nil
+	})
+	meta := &ProviderClients{PDNS: client}
+
+	d := schema.TestResourceDataRaw(t, resourcePDNSPTRRange().Schema, map[string]interface{}{
+		"cidr":              "10.0.0.0/30",
+		"hostname_template": "host-{ip}.dyn.example.com.",
+		"ttl":               300,
+	})
+	d.SetId("10.0.0.0/30")
+
+	assert.False(t, resourcePDNSPTRRangeDelete(context.Background(), d, meta).HasError())
+	assert.False(t, resourcePDNSPTRRangeRead(context.Background(), d, meta).HasError())
+	assert.Empty(t, d.Id())
+}
+
+func TestCheckPTRRangeOwnership(t *testing.T) {
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusOK, `{"name": "0.0.10.in-addr.arpa.", "rrsets": [
+			{"name": "1.0.0.10.in-addr.arpa.", "type": "PTR", "ttl": 300, "records": [{"content": "host-10-0-0-1.dyn.example.com."}]},
+			{"name": "2.0.0.10.in-addr.arpa.", "type": "PTR", "ttl": 300, "records": [{"content": "old-10-0-0-2.dyn.example.com."}]},
+			{"name": "3.0.0.10.in-addr.arpa.", "type": "PTR", "ttl": 300, "records": [{"content": "printer.example.com."}]}
+		]}`), nil
+	})
+	records := ptrRangeRecords{"0.0.10.in-addr.arpa.": {
+		"1.0.0.10.in-addr.arpa.": "host-10-0-0-1.dyn.example.com.",
+		"2.0.0.10.in-addr.arpa.": "host-10-0-0-2.dyn.example.com.",
+	}}
+	previous := ptrRangeRecords{"0.0.10.in-addr.arpa.": {
+		"2.0.0.10.in-addr.arpa.": "old-10-0-0-2.dyn.example.com.",
+	}}
+
+	// Records the range generated, now or with its previous template, are
+	// its own.
+	assert.NoError(t, checkPTRRangeOwnership(context.Background(), client, "0.0.10.in-addr.arpa.", records, previous))
+	err := checkPTRRangeOwnership(context.Background(), client, "0.0.10.in-addr.arpa.", records, nil)
+	assert.ErrorContains(t, err, "1 PTR records in zone 0.0.10.in-addr.arpa. already point to other names (2.0.0.10.in-addr.arpa. -> old-10-0-0-2.dyn.example.com.)")
+
+	// An address the range did not cover before is checked too.
+	records["0.0.10.in-addr.arpa."]["3.0.0.10.in-addr.arpa."] = "host-10-0-0-3.dyn.example.com."
+	err = checkPTRRangeOwnership(context.Background(), client, "0.0.10.in-addr.arpa.", records, previous)
+	assert.ErrorContains(t, err, "3.0.0.10.in-addr.arpa. -> printer.example.com.")
+}
+
+func TestPTRRangeReadReportsTTLDrift(t *testing.T) {
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/api/v1/servers/localhost/zones" {
+			return jsonResponse(http.StatusOK, `[{"id": "0.0.10.in-addr.arpa.", "name": "0.0.10.in-addr.arpa.", "kind": "Native"}]`), nil
+		}
+		return jsonResponse(http.StatusOK, `{"name": "0.0.10.in-addr.arpa.", "rrsets": [
+			{"name": "0.0.0.10.in-addr.arpa.", "type": "PTR", "ttl": 300, "records": [{"content": "host-10-0-0-0.dyn.example.com."}]},
+			{"name": "1.0.0.10.in-addr.arpa.", "type": "PTR", "ttl": 60, "records": [{"content": "host-10-0-0-1.dyn.example.com."}]}
+		]}`), nil
+	})
+
+	d := schema.TestResourceDataRaw(t, resourcePDNSPTRRange().Schema, map[string]interface{}{
+		"cidr":              "10.0.0.0/31",
+		"hostname_template": "host-{ip}.dyn.example.com.",
+		"ttl":               300,
+	})
+	d.SetId("10.0.0.0/31")
+
+	diags := resourcePDNSPTRRangeRead(context.Background(), d, &ProviderClients{PDNS: client})
+	if assert.False(t, diags.HasError(), diags) {
+		assert.Equal(t, 60, d.Get("ttl"))
+		assert.Equal(t, true, d.Get("in_sync"))
+	}
+}
+
+func TestParsePTRRangeImportID(t *testing.T) {
+	cidr, template, err := parsePTRRangeImportID("10.0.0.5/22:::host-{ip}.dyn.example.com.")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "10.0.0.0/22", cidr)
+		assert.Equal(t, "host-{ip}.dyn.example.com.", template)
+	}
+
+	cidr, template, err = parsePTRRangeImportID("2001:db8::/120:::host-{ip}.v6.example.com.")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "2001:db8::/120", cidr)
+		assert.Equal(t, "host-{ip}.v6.example.com.", template)
+	}
+
+	for _, importID := range []string{
+		"10.0.0.0/22",
+		"10.0.0.0/8:::host-{ip}.dyn.example.com.",
+		"10.0.0.0/22:::host-{host}.dyn.example.com.",
+	} {
+		_, _, err := parsePTRRangeImportID(importID)
+		assert.Error(t, err, importID)
+	}
+}
+
+func TestPTRRangeRRSetBatches(t *testing.T) {
+	addresses, err := ptrRangeAddresses("10.0.0.0/22", nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	records, _, err := newPTRRangeRecords([]ZoneInfo{{Name: "10.in-addr.arpa."}}, addresses, "host-{ip}.dyn.example.com.", false)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	batches := records.rrSetBatches("10.in-addr.arpa.", "REPLACE", 300)
+	if assert.Len(t, batches, 2) {
+		assert.Len(t, batches[0], ptrRangeBatchSize)
+		assert.Len(t, batches[1], 1024-ptrRangeBatchSize)
+		assert.Equal(t, ResourceRecordSet{
+			Name:       "0.0.0.10.in-addr.arpa.",
+			Type:       "PTR",
+			ChangeType: "REPLACE",
+			TTL:        300,
+			Records:    []Record{{Content: "host-10-0-0-0.dyn.example.com.", TTL: 300}},
+		}, batches[0][0])
+	}
+
+	for _, batch := range records.rrSetBatches("10.in-addr.arpa.", "DELETE", 0) {
+		for _, rrSet := range batch {
+			assert.Equal(t, "DELETE", rrSet.ChangeType)
+			assert.Empty(t, rrSet.Records)
+		}
+	}
+}
+
+func TestAccPowerDNSPTRRange_Basic(t *testing.T) {
+	resourceName := "powerdns_ptr_range.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerDNSPTRRangeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPowerDNSPTRRangeConfig("host-{ip}.dyn.example.com.", `["198.18.9.1"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "198.18.9.0/29"),
+					resource.TestCheckResourceAttr(resourceName, "in_sync", "true"),
+					resource.TestCheckResourceAttr(resourceName, "reverse_zones.0", "9.18.198.in-addr.arpa."),
+					testAccCheckPowerDNSPTRRangeRecord("198.18.9.2", "host-198-18-9-2.dyn.example.com."),
+					testAccCheckPowerDNSPTRRangeRecord("198.18.9.1", ""),
+				),
+			},
+			{
+				Config: testAccPowerDNSPTRRangeConfig("{d}.pool.example.com.", `["198.18.9.1", "198.18.9.2"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "in_sync", "true"),
+					testAccCheckPowerDNSPTRRangeRecord("198.18.9.3", "3.pool.example.com."),
+					testAccCheckPowerDNSPTRRangeRecord("198.18.9.2", ""),
+				),
+			},
+			{
+				// exclude cannot be imported, and the excluded addresses
+				// show up as missing records until the next apply.
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateId:           "198.18.9.0/29:::{d}.pool.example.com.",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"exclude", "in_sync"},
+			},
+		},
+	})
+}
+
+func TestAccPowerDNSPTRRange_InvalidTemplate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccPowerDNSPTRRangeConfig("host-{host}.dyn.example.com.", `[]`),
+				ExpectError: regexp.MustCompile(`unknown placeholder \{host\}`),
+			},
+		},
+	})
+}
+
+// testAccCheckPowerDNSPTRRangeRecord checks the PTR record of ip; an empty
+// hostname means the address must have no PTR record.
+func testAccCheckPowerDNSPTRRangeRecord(ip string, hostname string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ptrName, err := GetPTRRecordFQDN(ip, "")
+		if err != nil {
+			return err
+		}
+
+		client := testAccProvider.Meta().(*ProviderClients).PDNS
+		records, err := client.ListRecordsInRRSet(context.Background(), "9.18.198.in-addr.arpa.", ptrName, "PTR")
+		if err != nil {
+			return fmt.Errorf("error fetching PTR record %s: %w", ptrName, err)
+		}
+
+		switch {
+		case hostname == "" && len(records) > 0:
+			return fmt.Errorf("expected no PTR record for %s, got %v", ip, records)
+		case hostname != "" && !ptrOwnedBy(records, hostname):
+			return fmt.Errorf("expected PTR record for %s to point to %s, got %v", ip, hostname, records)
+		}
+		return nil
+	}
+}
+
+func testAccCheckPowerDNSPTRRangeDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ProviderClients).PDNS
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "powerdns_ptr_range" {
+			continue
+		}
+
+		records, err := client.ListRecords(context.Background(), "9.18.198.in-addr.arpa.")
+		if err != nil {
+			return nil
+		}
+		for _, record := range records {
+			if record.Type == "PTR" {
+				return fmt.Errorf("PTR range %s still has PTR record %s", rs.Primary.ID, record.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func testAccPowerDNSPTRRangeConfig(template string, exclude string) string {
+	return fmt.Sprintf(`
+resource "powerdns_reverse_zone" "test" {
+  cidr        = "198.18.9.0/24"
+  kind        = "Master"
+  nameservers = ["ns1.example.com."]
+}
+
+resource "powerdns_ptr_range" "test" {
+  cidr              = "198.18.9.0/29"
+  hostname_template = %q
+  ttl               = 300
+  exclude           = %s
+
+  depends_on = [powerdns_reverse_zone.test]
+}
+`, template, exclude)
+}
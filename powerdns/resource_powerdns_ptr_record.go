@@ -2,10 +2,7 @@ package powerdns
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"net"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -17,12 +14,15 @@ func resourcePDNSPTRRecord() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourcePDNSPTRRecordCreate,
 		ReadContext:   resourcePDNSPTRRecordRead,
+		UpdateContext: resourcePDNSPTRRecordUpdate,
 		DeleteContext: resourcePDNSPTRRecordDelete,
 
 		Importer: &schema.ResourceImporter{
 			StateContext: resourcePDNSPTRRecordImport,
 		},
 
+		CustomizeDiff: resourcePDNSPTRRecordCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"ip_address": {
 				Type:         schema.TypeString,
@@ -33,34 +33,54 @@ func resourcePDNSPTRRecord() *schema.Resource {
 			},
 			"hostname": {
 				Type:         schema.TypeString,
-				Required:     true,
-				ForceNew:     true,
+				Optional:     true,
+				ExactlyOneOf: []string{"hostname", "hostnames"},
 				ValidateFunc: ValidateFQDN,
-				Description:  "The hostname to point to. Must be a fully qualified domain name ending with a trailing dot.",
+				Description:  "The hostname to point to. Must be a fully qualified domain name ending with a trailing dot. Conflicts with hostnames.",
+			},
+			"hostnames": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: ValidateFQDN,
+				},
+				ExactlyOneOf: []string{"hostname", "hostnames"},
+				Description:  "The hostnames to point to, one PTR record each. Each must be a fully qualified domain name ending with a trailing dot. Conflicts with hostname.",
 			},
 			"ttl": {
 				Type:         schema.TypeInt,
 				Required:     true,
-				ForceNew:     true,
 				ValidateFunc: validation.IntAtLeast(0),
 				Description:  "The TTL of the PTR record.",
 			},
 			"reverse_zone": {
 				Type:         schema.TypeString,
-				Required:     true,
+				Optional:     true,
+				Computed:     true,
 				ForceNew:     true,
 				ValidateFunc: ValidateFQDN,
-				Description:  "The name of the reverse zone (e.g., '16.172.in-addr.arpa.' or '8.b.d.0.1.0.0.2.ip6.arpa.'). Must be a fully qualified domain name ending with a trailing dot.",
+				Description:  "The name of the reverse zone (e.g., '16.172.in-addr.arpa.' or '8.b.d.0.1.0.0.2.ip6.arpa.'). Must be a fully qualified domain name ending with a trailing dot. If omitted, the most specific reverse zone on the server that covers ip_address is used.",
 			},
 		},
 	}
 }
 
 func resourcePDNSPTRRecordCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourcePDNSPTRRecordUpsert(ctx, d, meta)
+}
+
+// resourcePDNSPTRRecordUpdate replaces the PTR RRset in place, so a changed
+// hostname or TTL never leaves the address without a PTR record.
+func resourcePDNSPTRRecordUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourcePDNSPTRRecordUpsert(ctx, d, meta)
+}
+
+func resourcePDNSPTRRecordUpsert(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*ProviderClients)
 
 	ipAddress := d.Get("ip_address").(string)
-	hostname := d.Get("hostname").(string)
 	ttl := d.Get("ttl").(int)
 	reverseZone := d.Get("reverse_zone").(string)
 
@@ -69,29 +89,23 @@ func resourcePDNSPTRRecordCreate(ctx context.Context, d *schema.ResourceData, me
 	tflog.Debug(ctx, "Creating PTR record")
 
 	// Get the PTR record name
-	ptrName, err := GetPTRRecordName(ipAddress)
+	ptrName, err := GetPTRRecordFQDN(ipAddress, reverseZone)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("failed to determine PTR record name: %w", err))
 	}
 
-	// Determine the correct suffix based on IP version
-	suffix := ".in-addr.arpa."
-	if net.ParseIP(ipAddress).To4() == nil {
-		suffix = ".ip6.arpa."
-	}
-
 	// Create the PTR record with full FQDN
 	rrSet := ResourceRecordSet{
-		Name:       ptrName + suffix,
+		Name:       ptrName,
 		Type:       "PTR",
 		TTL:        ttl,
 		ChangeType: "REPLACE",
-		Records: []Record{
-			{
-				Content: hostname,
-				TTL:     ttl,
-			},
-		},
+	}
+	for _, hostname := range configuredPTRHostnames(d) {
+		rrSet.Records = append(rrSet.Records, Record{
+			Content: hostname,
+			TTL:     ttl,
+		})
 	}
 
 	recID, err := client.PDNS.ReplaceRecordSet(ctx, reverseZone, rrSet)
@@ -119,37 +133,32 @@ func resourcePDNSPTRRecordRead(ctx context.Context, d *schema.ResourceData, meta
 	tflog.Debug(ctx, "Reading PTR record")
 
 	// Get the PTR record name
-	ptrName, err := GetPTRRecordName(ipAddress)
+	ptrName, err := GetPTRRecordFQDN(ipAddress, reverseZone)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("failed to determine PTR record name: %w", err))
 	}
 
-	// Determine the correct suffix based on IP version
-	suffix := ".in-addr.arpa."
-	if net.ParseIP(ipAddress).To4() == nil {
-		suffix = ".ip6.arpa."
-	}
-
-	records, err := client.PDNS.ListRecordsInRRSet(ctx, reverseZone, ptrName+suffix, "PTR")
+	records, err := client.PDNS.ListRecordsInRRSet(ctx, reverseZone, ptrName, "PTR")
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("couldn't fetch PTR record: %w", err))
 	}
 
 	if len(records) == 0 {
 		tflog.Warn(ctx, "PTR record not found; removing from state", map[string]any{
-			"ptr_name": ptrName + suffix,
+			"ptr_name": ptrName,
 		})
 		d.SetId("")
 		return nil
 	}
 
 	tflog.Debug(ctx, "Found PTR record", map[string]any{
-		"ptr_name": ptrName + suffix,
+		"ptr_name": ptrName,
 		"content":  records[0].Content,
 	})
 
-	if err := d.Set("hostname", records[0].Content); err != nil {
-		return diag.FromErr(fmt.Errorf("error setting hostname: %w", err))
+	_, useHostnames := d.GetOk("hostnames")
+	if err := setPTRHostnames(d, records, useHostnames); err != nil {
+		return diag.FromErr(err)
 	}
 	if err := d.Set("ttl", records[0].TTL); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting ttl: %w", err))
@@ -164,6 +173,82 @@ func resourcePDNSPTRRecordRead(ctx context.Context, d *schema.ResourceData, meta
 	return nil
 }
 
+// configuredPTRHostnames returns the PTR targets from hostname or hostnames,
+// whichever is set.
+func configuredPTRHostnames(d *schema.ResourceData) []string {
+	if hostnames, ok := d.GetOk("hostnames"); ok {
+		return expandStringSet(hostnames.(*schema.Set))
+	}
+	return []string{d.Get("hostname").(string)}
+}
+
+// setPTRHostnames stores the PTR targets read from PowerDNS. They go into
+// hostnames when the resource uses that attribute or when there is more than
+// one target, and into hostname otherwise.
+func setPTRHostnames(d *schema.ResourceData, records []Record, useHostnames bool) error {
+	hostname := ""
+	var hostnames []string
+	if useHostnames || len(records) > 1 {
+		for _, record := range records {
+			hostnames = append(hostnames, record.Content)
+		}
+	} else {
+		hostname = records[0].Content
+	}
+
+	if err := d.Set("hostname", hostname); err != nil {
+		return fmt.Errorf("error setting hostname: %w", err)
+	}
+	if err := d.Set("hostnames", hostnames); err != nil {
+		return fmt.Errorf("error setting hostnames: %w", err)
+	}
+	return nil
+}
+
+// resourcePDNSPTRRecordCustomizeDiff discovers the reverse zone when it is not
+// configured, and checks at plan time that the zone covers the IP address.
+func resourcePDNSPTRRecordCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	zoneConfigured := false
+	if rawConfig := d.GetRawConfig(); !rawConfig.IsNull() && rawConfig.IsKnown() {
+		zoneConfigured = !rawConfig.GetAttr("reverse_zone").IsNull()
+	}
+
+	// An unconfigured reverse zone is unknown until it has been discovered below.
+	if !d.NewValueKnown("ip_address") || (zoneConfigured && !d.NewValueKnown("reverse_zone")) {
+		return nil
+	}
+
+	ipAddress := d.Get("ip_address").(string)
+	reverseZone := d.Get("reverse_zone").(string)
+
+	if !zoneConfigured && (d.Id() == "" || d.HasChange("ip_address")) {
+		client := meta.(*ProviderClients)
+		zones, err := client.PDNS.ListZones(ctx)
+		if err != nil {
+			return fmt.Errorf("couldn't list zones to find the reverse zone of %s: %w", ipAddress, err)
+		}
+
+		reverseZone, err = findReverseZoneForIP(zones, ipAddress)
+		if err != nil {
+			return err
+		}
+		if err := d.SetNew("reverse_zone", reverseZone); err != nil {
+			return fmt.Errorf("error setting discovered reverse_zone: %w", err)
+		}
+		tflog.Debug(ctx, "Discovered reverse zone for PTR record", map[string]any{"ip_address": ipAddress, "reverse_zone": reverseZone})
+	}
+
+	ptrName, err := GetPTRRecordFQDN(ipAddress, reverseZone)
+	if err != nil {
+		return err
+	}
+	if !recordNameInZone(ptrName, reverseZone) {
+		return fmt.Errorf("reverse zone %s does not cover IP address %s", reverseZone, ipAddress)
+	}
+
+	return nil
+}
+
 func resourcePDNSPTRRecordDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*ProviderClients)
 
@@ -175,23 +260,17 @@ func resourcePDNSPTRRecordDelete(ctx context.Context, d *schema.ResourceData, me
 	tflog.Debug(ctx, "Deleting PTR record")
 
 	// Get the PTR record name
-	ptrName, err := GetPTRRecordName(ipAddress)
+	ptrName, err := GetPTRRecordFQDN(ipAddress, reverseZone)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("failed to determine PTR record name: %w", err))
 	}
 
-	// Determine the correct suffix based on IP version
-	suffix := ".in-addr.arpa."
-	if net.ParseIP(ipAddress).To4() == nil {
-		suffix = ".ip6.arpa."
-	}
-
-	if err := client.PDNS.DeleteRecordSet(ctx, reverseZone, ptrName+suffix, "PTR"); err != nil {
+	if err := client.PDNS.DeleteRecordSet(ctx, reverseZone, ptrName, "PTR"); err != nil {
 		return diag.FromErr(fmt.Errorf("error deleting PTR record: %w", err))
 	}
 
 	tflog.Info(ctx, "Successfully deleted PTR record", map[string]any{
-		"ptr_name": ptrName + suffix,
+		"ptr_name": ptrName,
 	})
 	return nil
 }
@@ -201,19 +280,12 @@ func resourcePDNSPTRRecordImport(ctx context.Context, d *schema.ResourceData, me
 
 	tflog.Info(ctx, "Importing PTR record", map[string]any{"id": d.Id()})
 
-	var data map[string]string
-	if err := json.Unmarshal([]byte(d.Id()), &data); err != nil {
+	zone, recordID, err := parseRecordImportID(d.Id())
+	if err != nil {
 		return nil, err
 	}
-
-	zone, ok := data["zone"]
-	if !ok {
-		return nil, fmt.Errorf("missing zone in import data")
-	}
-
-	recordID, ok := data["id"]
-	if !ok {
-		return nil, fmt.Errorf("missing id in import data")
+	if _, typ, _ := parseID(recordID); typ != "PTR" {
+		return nil, fmt.Errorf("record type %s cannot be imported into powerdns_ptr_record, expected PTR", typ)
 	}
 
 	tflog.Debug(ctx, "Fetching PTR record for import", map[string]any{
@@ -240,16 +312,19 @@ func resourcePDNSPTRRecordImport(ctx context.Context, d *schema.ResourceData, me
 	if err := d.Set("reverse_zone", zone); err != nil {
 		return nil, fmt.Errorf("error setting reverse_zone: %w", err)
 	}
-	if err := d.Set("hostname", records[0].Content); err != nil {
-		return nil, fmt.Errorf("error setting hostname: %w", err)
+	if err := setPTRHostnames(d, records, false); err != nil {
+		return nil, err
 	}
 	if err := d.Set("ttl", records[0].TTL); err != nil {
 		return nil, fmt.Errorf("error setting ttl: %w", err)
 	}
 
 	// Extract IP address from PTR record name
-	r := strings.Split(recordID, ":::")
-	ip, err := ParsePTRRecordName(r[0])
+	ptrName, _, err := parseID(recordID)
+	if err != nil {
+		return nil, err
+	}
+	ip, err := ParsePTRRecordName(ptrName)
 	if err != nil {
 		return nil, err
 	}
@@ -3,6 +3,7 @@ package powerdns
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -25,6 +26,12 @@ func TestAccPowerDNSPTRRecord_Basic(t *testing.T) {
 					resource.TestCheckResourceAttr("powerdns_ptr_record.test", "reverse_zone", "10.in-addr.arpa."),
 				),
 			},
+			{
+				ResourceName:      "powerdns_ptr_record.test",
+				ImportStateId:     "10.in-addr.arpa./3.2.1.10.in-addr.arpa./PTR",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -124,6 +131,88 @@ resource "powerdns_ptr_record" "test_ipv6" {
 }
 `
 
+func TestAccPowerDNSPTRRecord_Hostnames(t *testing.T) {
+	resourceName := "powerdns_ptr_record.multi"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerDNSPTRRecordDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPowerDNSPTRRecordConfig_Hostnames,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPowerDNSPTRRecordExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "hostname", ""),
+					resource.TestCheckResourceAttr(resourceName, "hostnames.#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "hostnames.*", "web.example.com."),
+					resource.TestCheckTypeSetElemAttr(resourceName, "hostnames.*", "mail.example.com."),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportStateId:     "10.in-addr.arpa./4.2.1.10.in-addr.arpa./PTR",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccPowerDNSPTRRecordConfig_SingleHostname,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPowerDNSPTRRecordExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "hostname", "web.example.com."),
+					resource.TestCheckResourceAttr(resourceName, "hostnames.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPowerDNSPTRRecord_HostnameConflict(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccPowerDNSPTRRecordConfig_HostnameConflict,
+				ExpectError: regexp.MustCompile(`only one of .hostname,hostnames. can be specified`),
+			},
+		},
+	})
+}
+
+func TestAccPowerDNSPTRRecord_DiscoveredReverseZone(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerDNSPTRRecordDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPowerDNSPTRRecordConfig_DiscoveryZones,
+			},
+			{
+				Config: testAccPowerDNSPTRRecordConfig_Discovered,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPowerDNSPTRRecordExists("powerdns_ptr_record.discovered"),
+					resource.TestCheckResourceAttr("powerdns_ptr_record.discovered", "reverse_zone", "5.20.172.in-addr.arpa."),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPowerDNSPTRRecord_NoReverseZone(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccPowerDNSPTRRecordConfig_NoReverseZone,
+				ExpectError: regexp.MustCompile("couldn't determine the reverse zone for IP address 198.51.100.1"),
+			},
+		},
+	})
+}
+
 func testAccCheckPowerDNSPTRRecordDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*ProviderClients).PDNS
 
@@ -167,3 +256,73 @@ func testAccCheckPowerDNSPTRRecordExists(n string) resource.TestCheckFunc {
 		return nil
 	}
 }
+
+const testAccPowerDNSPTRRecordConfig_DiscoveryZones = `
+resource "powerdns_reverse_zone" "test_16" {
+  cidr        = "172.20.0.0/16"
+  kind        = "Master"
+  nameservers = ["ns1.example.com."]
+}
+
+resource "powerdns_reverse_zone" "test_24" {
+  cidr        = "172.20.5.0/24"
+  kind        = "Master"
+  nameservers = ["ns1.example.com."]
+}
+`
+
+const testAccPowerDNSPTRRecordConfig_Discovered = testAccPowerDNSPTRRecordConfig_DiscoveryZones + `
+resource "powerdns_ptr_record" "discovered" {
+  ip_address = "172.20.5.9"
+  hostname   = "host.example.com."
+  ttl        = 300
+}
+`
+
+const testAccPowerDNSPTRRecordConfig_NoReverseZone = `
+resource "powerdns_ptr_record" "orphan" {
+  ip_address = "198.51.100.1"
+  hostname   = "host.example.com."
+  ttl        = 300
+}
+`
+
+const testAccPowerDNSPTRRecordConfig_Hostnames = `
+resource "powerdns_reverse_zone" "test" {
+  cidr        = "10.0.0.0/8"
+  kind        = "Master"
+  nameservers = ["ns1.example.com."]
+}
+
+resource "powerdns_ptr_record" "multi" {
+  ip_address   = "10.1.2.4"
+  hostnames    = ["web.example.com.", "mail.example.com."]
+  ttl          = 300
+  reverse_zone = powerdns_reverse_zone.test.name
+}
+`
+
+const testAccPowerDNSPTRRecordConfig_SingleHostname = `
+resource "powerdns_reverse_zone" "test" {
+  cidr        = "10.0.0.0/8"
+  kind        = "Master"
+  nameservers = ["ns1.example.com."]
+}
+
+resource "powerdns_ptr_record" "multi" {
+  ip_address   = "10.1.2.4"
+  hostname     = "web.example.com."
+  ttl          = 300
+  reverse_zone = powerdns_reverse_zone.test.name
+}
+`
+
+const testAccPowerDNSPTRRecordConfig_HostnameConflict = `
+resource "powerdns_ptr_record" "conflict" {
+  ip_address   = "10.1.2.5"
+  hostname     = "web.example.com."
+  hostnames    = ["mail.example.com."]
+  ttl          = 300
+  reverse_zone = "10.in-addr.arpa."
+}
+`
@@ -2,7 +2,7 @@ package powerdns
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -23,18 +23,24 @@ func resourcePDNSRecord() *schema.Resource {
 			StateContext: resourcePDNSRecordImport,
 		},
 
+		CustomizeDiff: resourcePDNSRecordCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"zone": {
 				Type:         schema.TypeString,
-				Required:     true,
+				Optional:     true,
+				Computed:     true,
 				ForceNew:     true,
 				ValidateFunc: ValidateZoneName,
+				Description:  "The zone to contain this record. If omitted, the zone is inferred from an absolute name by the longest matching zone on the server.",
 			},
 			"name": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ForceNew:     true,
-				ValidateFunc: ValidateFQDN,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     ValidateRecordName,
+				DiffSuppressFunc: suppressEquivalentRecordNameDiff,
+				Description:      "The record name: a fully qualified name with a trailing dot, \"@\" for the zone apex, or a name relative to zone such as \"www\".",
 			},
 			"type": {
 				Type:     schema.TypeString,
@@ -71,8 +77,15 @@ func resourcePDNSRecord() *schema.Resource {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				ForceNew:    true,
+				Deprecated:  "set_ptr relies on the set-ptr flag that PowerDNS 4.5 removed. Use the powerdns_host resource to manage A/AAAA records together with their PTR records.",
 				Description: "For A and AAAA records, if true, create corresponding PTR.",
 			},
+			"txt_auto_quote": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "For TXT and SPF records, treat each entry in records as a plain string. The provider quotes, escapes and splits it into 255-byte character-strings on write, and reassembles it on read.",
+			},
 		},
 	}
 }
@@ -89,7 +102,20 @@ func resourcePDNSRecordUpsert(ctx context.Context, d *schema.ResourceData, meta
 	client := meta.(*ProviderClients)
 
 	zone := d.Get("zone").(string)
-	name := d.Get("name").(string)
+	if zone == "" {
+		// The plan could not infer the zone because it did not exist yet.
+		zones, err := client.PDNS.ListZones(ctx)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("couldn't list zones to infer the zone of %s: %w", d.Get("name").(string), err))
+		}
+		if zone, err = findZoneForName(zones, d.Get("name").(string)); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("zone", zone); err != nil {
+			return diag.FromErr(fmt.Errorf("error setting inferred zone: %w", err))
+		}
+	}
+	name := resolveRecordName(d.Get("name").(string), zone)
 	typ := d.Get("type").(string)
 	ttl := d.Get("ttl").(int)
 	disabled := configuredRRSetDisabledValue(d.GetRawConfig(), d.Get("disabled").(bool))
@@ -114,6 +140,8 @@ func resourcePDNSRecordUpsert(ctx context.Context, d *schema.ResourceData, meta
 		return diag.FromErr(fmt.Errorf("'records' must not be empty"))
 	}
 
+	txtAutoQuote := d.Get("txt_auto_quote").(bool)
+
 	rrSet := ResourceRecordSet{
 		Name: name,
 		Type: typ,
@@ -133,6 +161,9 @@ func resourcePDNSRecordUpsert(ctx context.Context, d *schema.ResourceData, meta
 	records := make([]Record, 0, len(recList))
 	for _, rc := range recList {
 		content := rc.(string)
+		if txtAutoQuote {
+			content = encodeTXTContent(content)
+		}
 		recordDisabled := recordDisabledValue(disabledByContent, content, disabled)
 
 		records = append(records, Record{
@@ -184,9 +215,9 @@ func resourcePDNSRecordRead(ctx context.Context, d *schema.ResourceData, meta in
 		return nil
 	}
 
-	recs := make([]string, 0, len(records))
-	for _, r := range records {
-		recs = append(recs, r.Content)
+	recs, err := flattenRecordContents(records, d.Get("txt_auto_quote").(bool))
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	comments := flattenRRSetComments(nil)
@@ -206,8 +237,12 @@ func resourcePDNSRecordRead(ctx context.Context, d *schema.ResourceData, meta in
 	if err := d.Set("ttl", records[0].TTL); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting PowerDNS TTL: %w", err))
 	}
-	if err := d.Set("name", records[0].Name); err != nil {
-		return diag.FromErr(fmt.Errorf("error setting PowerDNS Name: %w", err))
+	// Keep a relative name from the configuration as long as it still
+	// resolves to the RRset that was read.
+	if !strings.EqualFold(resolveRecordName(d.Get("name").(string), zone), records[0].Name) {
+		if err := d.Set("name", records[0].Name); err != nil {
+			return diag.FromErr(fmt.Errorf("error setting PowerDNS Name: %w", err))
+		}
 	}
 	if err := d.Set("type", records[0].Type); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting PowerDNS Type: %w", err))
@@ -216,6 +251,118 @@ func resourcePDNSRecordRead(ctx context.Context, d *schema.ResourceData, meta in
 	return nil
 }
 
+// resourcePDNSRecordCustomizeDiff infers the zone when it is not configured,
+// replaces the record when only txt_auto_quote changes, and checks at plan
+// time that the record name lies inside its zone and does not collide with a
+// CNAME, so PowerDNS does not reject the change mid-apply.
+func resourcePDNSRecordCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.NewValueKnown("type") {
+		if err := validateTXTAutoQuote(d.Get("txt_auto_quote").(bool), d.Get("type").(string)); err != nil {
+			return err
+		}
+	}
+
+	// Toggling txt_auto_quote on unchanged records changes the content sent
+	// to PowerDNS, which the records diff alone would not show.
+	if d.Id() != "" && d.HasChange("txt_auto_quote") && !d.HasChange("records") {
+		if err := d.ForceNew("txt_auto_quote"); err != nil {
+			return fmt.Errorf("error forcing replacement on txt_auto_quote change: %w", err)
+		}
+	}
+
+	zoneConfigured := false
+	if rawConfig := d.GetRawConfig(); !rawConfig.IsNull() && rawConfig.IsKnown() {
+		zoneConfigured = !rawConfig.GetAttr("zone").IsNull()
+	}
+
+	// An unconfigured zone is unknown until it has been inferred below.
+	if !d.NewValueKnown("name") || (zoneConfigured && !d.NewValueKnown("zone")) {
+		return nil
+	}
+
+	name := d.Get("name").(string)
+	zone := d.Get("zone").(string)
+
+	if !zoneConfigured && (d.Id() == "" || d.HasChange("name")) {
+		if isRecordNameRelative(name) {
+			return fmt.Errorf("zone must be set when name %q is relative", name)
+		}
+
+		client := meta.(*ProviderClients)
+		zones, err := client.PDNS.ListZones(ctx)
+		if err != nil {
+			return fmt.Errorf("couldn't list zones to infer the zone of %s: %w", name, err)
+		}
+
+		zone, err = findZoneForName(zones, name)
+		if errors.Is(err, errNoZoneForName) {
+			// The zone may be created in the same apply, so it is inferred
+			// again when the record is created.
+			tflog.Debug(ctx, "No zone found for PowerDNS record yet; inferring it on apply", map[string]any{"name": name})
+			return d.SetNewComputed("zone")
+		}
+		if err != nil {
+			return err
+		}
+		if err := d.SetNew("zone", zone); err != nil {
+			return fmt.Errorf("error setting inferred zone: %w", err)
+		}
+		tflog.Debug(ctx, "Inferred zone for PowerDNS record", map[string]any{"name": name, "zone": zone})
+	}
+
+	if zone == "" {
+		return fmt.Errorf("zone must be set for record %s", name)
+	}
+
+	fqdn := resolveRecordName(name, zone)
+	if !recordNameInZone(fqdn, zone) {
+		return fmt.Errorf("record name %s is not inside zone %s", fqdn, zone)
+	}
+
+	if !d.NewValueKnown("type") || !d.NewValueKnown("records") {
+		return nil
+	}
+
+	return checkRecordConflicts(ctx, d, meta, zone, fqdn)
+}
+
+// checkRecordConflicts compares the planned RRset with the live zone. Only
+// new RRsets and changes that can introduce a conflict are checked, so an
+// unchanged record does not cost an API call on every plan.
+func checkRecordConflicts(ctx context.Context, d *schema.ResourceDiff, meta interface{}, zone string, name string) error {
+	typ := d.Get("type").(string)
+	recordCount := d.Get("records").(*schema.Set).Len()
+
+	if d.Id() != "" && !d.HasChanges("zone", "name", "type", "records") {
+		return nil
+	}
+
+	// A record moving to another zone leaves nothing behind in the new one.
+	ownID := d.Id()
+	if d.HasChange("zone") {
+		ownID = ""
+	}
+
+	client := meta.(*ProviderClients)
+	existing, err := client.PDNS.ListRecords(ctx, zone)
+	if err != nil {
+		return fmt.Errorf("couldn't list records of zone %s to check for conflicts: %w", zone, err)
+	}
+
+	return findRecordConflict(zone, name, typ, recordCount, ownID, existing)
+}
+
+// suppressEquivalentRecordNameDiff treats a relative and an absolute name as
+// equal when they resolve to the same FQDN in the record's zone.
+func suppressEquivalentRecordNameDiff(k, old, new string, d *schema.ResourceData) bool {
+	if old == "" || new == "" {
+		return false
+	}
+
+	zone := d.Get("zone").(string)
+	return strings.EqualFold(resolveRecordName(old, zone), resolveRecordName(new, zone))
+}
+
 func resourcePDNSRecordDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*ProviderClients)
 
@@ -239,20 +386,12 @@ func resourcePDNSRecordImport(ctx context.Context, d *schema.ResourceData, meta
 
 	tflog.Info(ctx, "Importing PowerDNS Record", map[string]any{"id": d.Id()})
 
-	var data map[string]string
-	if err := json.Unmarshal([]byte(d.Id()), &data); err != nil {
+	importID := d.Id()
+	zoneName, recordID, err := parseRecordImportID(importID)
+	if err != nil {
 		return nil, err
 	}
 
-	zoneName, ok := data["zone"]
-	if !ok {
-		return nil, fmt.Errorf("missing zone name in input data")
-	}
-	recordID, ok := data["id"]
-	if !ok {
-		return nil, fmt.Errorf("missing record id in input data")
-	}
-
 	tflog.Debug(ctx, "Fetching record for import", map[string]any{
 		"zone": zoneName, "recordID": recordID,
 	})
@@ -266,9 +405,18 @@ func resourcePDNSRecordImport(ctx context.Context, d *schema.ResourceData, meta
 		return nil, fmt.Errorf("rrset has no records to import")
 	}
 
-	recs := make([]string, 0, len(records))
-	for _, r := range records {
-		recs = append(recs, r.Content)
+	// The plain strings are imported when the import ID enables
+	// txt_auto_quote, so they match a configuration that uses it.
+	txtAutoQuote, err := parseRecordImportTXTAutoQuote(importID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateTXTAutoQuote(txtAutoQuote, records[0].Type); err != nil {
+		return nil, err
+	}
+	recs, err := flattenRecordContents(records, txtAutoQuote)
+	if err != nil {
+		return nil, err
 	}
 
 	comments := flattenRRSetComments(nil)
@@ -297,11 +445,35 @@ func resourcePDNSRecordImport(ctx context.Context, d *schema.ResourceData, meta
 	if err := d.Set("disabled", rrSetDisabled(records)); err != nil {
 		return nil, fmt.Errorf("error setting PowerDNS Disabled: %w", err)
 	}
+	if err := d.Set("txt_auto_quote", txtAutoQuote); err != nil {
+		return nil, fmt.Errorf("error setting PowerDNS TXT Auto Quote: %w", err)
+	}
 
 	d.SetId(recordID)
 	return []*schema.ResourceData{d}, nil
 }
 
+// flattenRecordContents returns the record contents as they are kept in state.
+// With txt_auto_quote enabled, TXT content is decoded back to the plain string
+// the user configured.
+func flattenRecordContents(records []Record, txtAutoQuote bool) ([]string, error) {
+	contents := make([]string, 0, len(records))
+	for _, r := range records {
+		if !txtAutoQuote {
+			contents = append(contents, r.Content)
+			continue
+		}
+
+		value, err := decodeTXTContent(r.Content)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding TXT content: %w", err)
+		}
+		contents = append(contents, value)
+	}
+
+	return contents, nil
+}
+
 func configuredRRSetComments(d *schema.ResourceData) *[]Comment {
 	if d.HasChange("comments") {
 		_, newComments := d.GetChange("comments")
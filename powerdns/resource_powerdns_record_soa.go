@@ -2,7 +2,6 @@ package powerdns
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -245,18 +244,12 @@ func resourcePDNSRecordSOAImport(ctx context.Context, d *schema.ResourceData, me
 
 	tflog.Info(ctx, "Importing PowerDNS SOA record", map[string]any{"id": d.Id()})
 
-	var data map[string]string
-	if err := json.Unmarshal([]byte(d.Id()), &data); err != nil {
+	zoneName, recordID, err := parseRecordImportID(d.Id())
+	if err != nil {
 		return nil, err
 	}
-
-	zoneName, ok := data["zone"]
-	if !ok {
-		return nil, fmt.Errorf("missing zone name in input data")
-	}
-	recordID, ok := data["id"]
-	if !ok {
-		return nil, fmt.Errorf("missing record id in input data")
+	if _, typ, _ := parseID(recordID); typ != "SOA" {
+		return nil, fmt.Errorf("record type %s cannot be imported into powerdns_record_soa, expected SOA", typ)
 	}
 
 	tflog.Debug(ctx, "Fetching SOA record for import", map[string]any{
@@ -113,6 +113,12 @@ func TestAccPDNSRecordSOA_Import(t *testing.T) {
 					return nil
 				},
 			},
+			{
+				ResourceName:      resourceName,
+				ImportStateId:     "test-soa-imp-sysa.xyz./@/SOA",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
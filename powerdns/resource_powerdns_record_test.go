@@ -3,12 +3,16 @@ package powerdns
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestRRSetDisabledConfigured(t *testing.T) {
@@ -181,6 +185,85 @@ func TestConfiguredRRSetDisabledValue(t *testing.T) {
 	}
 }
 
+func TestResourcePDNSRecordImportTXTAutoQuote(t *testing.T) {
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/api/v1/servers/localhost/zones/example.com.", r.URL.Path)
+		return jsonResponse(http.StatusOK, `{"name": "example.com.", "rrsets": [
+			{"name": "www.example.com.", "type": "TXT", "ttl": 300, "records": [{"content": "\"hello\" \"world\""}]}
+		]}`), nil
+	})
+	meta := &ProviderClients{PDNS: client}
+	r := resourcePDNSRecord()
+
+	d := r.TestResourceData()
+	d.SetId(`{"zone": "example.com.", "id": "www:::TXT", "txt_auto_quote": true}`)
+	imported, err := resourcePDNSRecordImport(context.Background(), d, meta)
+	if assert.NoError(t, err) && assert.Len(t, imported, 1) {
+		assert.Equal(t, "www.example.com.:::TXT", imported[0].Id())
+		assert.Equal(t, true, imported[0].Get("txt_auto_quote"))
+		assert.Equal(t, []interface{}{"helloworld"}, imported[0].Get("records").(*schema.Set).List())
+	}
+
+	// Without the option the raw content is imported.
+	d = r.TestResourceData()
+	d.SetId("example.com./www/TXT")
+	imported, err = resourcePDNSRecordImport(context.Background(), d, meta)
+	if assert.NoError(t, err) && assert.Len(t, imported, 1) {
+		assert.Equal(t, false, imported[0].Get("txt_auto_quote"))
+		assert.Equal(t, []interface{}{`"hello" "world"`}, imported[0].Get("records").(*schema.Set).List())
+	}
+}
+
+func TestResourcePDNSRecordTXTAutoQuoteToggle(t *testing.T) {
+	// Changed records are checked for conflicts against the live zone.
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusOK, `{"name": "example.com.", "rrsets": [
+			{"name": "www.example.com.", "type": "TXT", "ttl": 300, "records": [{"content": "\"hello\""}]}
+		]}`), nil
+	})
+	r := resourcePDNSRecord()
+	sm := schema.InternalMap(r.Schema)
+	state := &terraform.InstanceState{
+		ID: "www.example.com.:::TXT",
+		Attributes: map[string]string{
+			"id":             "www.example.com.:::TXT",
+			"zone":           "example.com.",
+			"name":           "www.example.com.",
+			"type":           "TXT",
+			"ttl":            "300",
+			"txt_auto_quote": "false",
+			"records.#":      "1",
+			fmt.Sprintf("records.%d", schema.HashString(`"hello"`)): `"hello"`,
+		},
+		RawConfig: cty.ObjectVal(map[string]cty.Value{"zone": cty.StringVal("example.com.")}),
+	}
+	diffFor := func(records string) *terraform.InstanceDiff {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"zone":           "example.com.",
+			"name":           "www.example.com.",
+			"type":           "TXT",
+			"ttl":            300,
+			"txt_auto_quote": true,
+			"records":        []interface{}{records},
+		})
+		diff, err := sm.Diff(context.Background(), state, config, r.CustomizeDiff, &ProviderClients{PDNS: client}, true)
+		assert.NoError(t, err)
+		return diff
+	}
+
+	// The same records would be sent quoted twice, so the record is replaced.
+	diff := diffFor(`"hello"`)
+	if assert.Contains(t, diff.Attributes, "txt_auto_quote") {
+		assert.True(t, diff.Attributes["txt_auto_quote"].RequiresNew)
+	}
+
+	// Changed records show the new content in their own diff.
+	diff = diffFor("hello")
+	if assert.Contains(t, diff.Attributes, "txt_auto_quote") {
+		assert.False(t, diff.Attributes["txt_auto_quote"].RequiresNew)
+	}
+}
+
 func TestAccPDNSRecord_Empty(t *testing.T) {
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:  func() { testAccPreCheck(t) },
@@ -216,6 +299,24 @@ func TestAccPDNSRecord_A(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				ResourceName:      resourceName,
+				ImportStateId:     "rec-a.sysa.xyz./test.rec-a.sysa.xyz./A",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      resourceName,
+				ImportStateId:     "rec-a.sysa.xyz.:::test:::A",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  resourceName,
+				ImportStateId: "test.rec-a.sysa.xyz.:::A",
+				ImportState:   true,
+				ExpectError:   regexp.MustCompile("expected one of"),
+			},
 		},
 	})
 }
@@ -688,6 +789,147 @@ func TestAccPDNSRecord_TXT(t *testing.T) {
 	})
 }
 
+func TestAccPDNSRecord_TXTAutoQuote(t *testing.T) {
+	resourceName := "powerdns_record.test-txt-auto-quote"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSRecordDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testPDNSRecordConfigTXTAutoQuote,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPDNSRecordExists(resourceName),
+					resource.TestCheckTypeSetElemAttr(resourceName, "records.*", "v=DKIM1; k=rsa; p="+strings.Repeat("A", 300)),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportStateId:     `{"zone": "rec-txt-auto-quote.sysa.xyz.", "id": "dkim._domainkey:::TXT", "txt_auto_quote": true}`,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccPDNSRecord_TXTAutoQuoteWrongType(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSRecordDestroy,
+		Steps: []resource.TestStep{
+			{
+				// The plan fails, so neither the zone nor the record is
+				// created.
+				Config:      testPDNSRecordConfigTXTAutoQuoteWrongType,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("txt_auto_quote is only supported for TXT and SPF records, got A"),
+			},
+		},
+	})
+}
+
+func TestAccPDNSRecord_RelativeName(t *testing.T) {
+	resourceName := "powerdns_record.test-relative"
+	apexResourceName := "powerdns_record.test-apex"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSRecordDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testPDNSRecordConfigRelativeName,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPDNSRecordExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "id", "www.rec-relative.sysa.xyz.:::A"),
+					resource.TestCheckResourceAttr(resourceName, "name", "www"),
+					testAccCheckPDNSRecordExists(apexResourceName),
+					resource.TestCheckResourceAttr(apexResourceName, "id", "rec-relative.sysa.xyz.:::TXT"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPDNSRecord_InferredZone(t *testing.T) {
+	resourceName := "powerdns_record.test-inferred"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSRecordDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testPDNSRecordConfigInferredZoneSetup,
+			},
+			{
+				Config: testPDNSRecordConfigInferredZone,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPDNSRecordExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "zone", "sub.rec-inferred.sysa.xyz."),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPDNSRecord_InferredZoneCreatedInSameApply(t *testing.T) {
+	resourceName := "powerdns_record.test-inferred"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSRecordDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testPDNSRecordConfigInferredZoneSameApply,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPDNSRecordExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "zone", "rec-inferred-same-apply.example."),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPDNSRecord_NameOutsideZone(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testPDNSRecordConfigNameOutsideZone,
+				ExpectError: regexp.MustCompile("is not inside zone"),
+			},
+		},
+	})
+}
+
+func TestAccPDNSRecord_CNAMEConflict(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSRecordDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testPDNSRecordConfigCNAMEConflictSetup,
+				Check:  testAccCheckPDNSRecordExists("powerdns_record.test-a"),
+			},
+			{
+				Config:      testPDNSRecordConfigCNAMEConflict,
+				ExpectError: regexp.MustCompile(`conflicts with existing RRset www\.rec-cname-conflict\.sysa\.xyz\.:::A`),
+			},
+			{
+				Config:      testPDNSRecordConfigCNAMEAtApex,
+				ExpectError: regexp.MustCompile("cannot be created at the apex"),
+			},
+		},
+	})
+}
+
 func TestAccPDNSRecord_ALIAS(t *testing.T) {
 	resourceName := "powerdns_record.test-alias"
 	resourceID := `{"zone":"rec-alias.sysa.xyz.","id":"alias.rec-alias.sysa.xyz.:::ALIAS"}`
@@ -1106,6 +1348,141 @@ resource "powerdns_record" "test-txt" {
 	records = [ "\"text record payload\"" ]
 }`
 
+var testPDNSRecordConfigTXTAutoQuote = `
+resource "powerdns_zone" "test-zone" {
+	name = "rec-txt-auto-quote.sysa.xyz."
+	kind = "Native"
+}
+
+resource "powerdns_record" "test-txt-auto-quote" {
+	zone = powerdns_zone.test-zone.name
+	name = "dkim._domainkey.rec-txt-auto-quote.sysa.xyz."
+	type = "TXT"
+	ttl = 60
+	txt_auto_quote = true
+	records = [ "v=DKIM1; k=rsa; p=` + strings.Repeat("A", 300) + `" ]
+}`
+
+const testPDNSRecordConfigTXTAutoQuoteWrongType = `
+resource "powerdns_zone" "test-zone" {
+	name = "rec-txt-auto-quote-wrong-type.sysa.xyz."
+	kind = "Native"
+}
+
+resource "powerdns_record" "test-txt-auto-quote" {
+	zone = powerdns_zone.test-zone.name
+	name = "host.rec-txt-auto-quote-wrong-type.sysa.xyz."
+	type = "A"
+	ttl = 60
+	txt_auto_quote = true
+	records = [ "192.0.2.10" ]
+}`
+
+const testPDNSRecordConfigRelativeName = `
+resource "powerdns_zone" "test-zone" {
+	name = "rec-relative.sysa.xyz."
+	kind = "Native"
+}
+
+resource "powerdns_record" "test-relative" {
+	zone = powerdns_zone.test-zone.name
+	name = "www"
+	type = "A"
+	ttl = 60
+	records = [ "192.0.2.10" ]
+}
+
+resource "powerdns_record" "test-apex" {
+	zone = powerdns_zone.test-zone.name
+	name = "@"
+	type = "TXT"
+	ttl = 60
+	records = [ "\"apex\"" ]
+}`
+
+const testPDNSRecordConfigInferredZoneSetup = `
+resource "powerdns_zone" "test-zone" {
+	name = "rec-inferred.sysa.xyz."
+	kind = "Native"
+}
+
+resource "powerdns_zone" "test-subzone" {
+	name = "sub.rec-inferred.sysa.xyz."
+	kind = "Native"
+}`
+
+const testPDNSRecordConfigInferredZone = testPDNSRecordConfigInferredZoneSetup + `
+
+resource "powerdns_record" "test-inferred" {
+	name = "www.sub.rec-inferred.sysa.xyz."
+	type = "A"
+	ttl = 60
+	records = [ "192.0.2.11" ]
+}`
+
+const testPDNSRecordConfigInferredZoneSameApply = `
+resource "powerdns_zone" "test-zone" {
+	name = "rec-inferred-same-apply.example."
+	kind = "Native"
+}
+
+resource "powerdns_record" "test-inferred" {
+	name = "www.rec-inferred-same-apply.example."
+	type = "A"
+	ttl = 60
+	records = [ "192.0.2.12" ]
+
+	depends_on = [powerdns_zone.test-zone]
+}`
+
+const testPDNSRecordConfigNameOutsideZone = `
+resource "powerdns_zone" "test-zone" {
+	name = "rec-outside.sysa.xyz."
+	kind = "Native"
+}
+
+resource "powerdns_record" "test-outside" {
+	zone = powerdns_zone.test-zone.name
+	name = "www.example.org."
+	type = "A"
+	ttl = 60
+	records = [ "192.0.2.12" ]
+}`
+
+const testPDNSRecordConfigCNAMEConflictSetup = `
+resource "powerdns_zone" "test-zone" {
+	name = "rec-cname-conflict.sysa.xyz."
+	kind = "Native"
+}
+
+resource "powerdns_record" "test-a" {
+	zone = powerdns_zone.test-zone.name
+	name = "www.rec-cname-conflict.sysa.xyz."
+	type = "A"
+	ttl = 60
+	records = [ "192.0.2.20" ]
+}`
+
+const testPDNSRecordConfigCNAMEConflict = testPDNSRecordConfigCNAMEConflictSetup + `
+
+resource "powerdns_record" "test-cname" {
+	zone = powerdns_zone.test-zone.name
+	name = "www.rec-cname-conflict.sysa.xyz."
+	type = "CNAME"
+	ttl = 60
+	records = [ "target.sysa.xyz." ]
+}`
+
+const testPDNSRecordConfigCNAMEAtApex = testPDNSRecordConfigCNAMEConflictSetup + `
+
+resource "powerdns_record" "test-cname" {
+	zone = powerdns_zone.test-zone.name
+	name = "@"
+	type = "CNAME"
+	ttl = 60
+	records = [ "target.sysa.xyz." ]
+}`
+
 const testPDNSRecordConfigALIAS = `
 resource "powerdns_zone" "test-zone" {
 	name = "rec-alias.sysa.xyz."
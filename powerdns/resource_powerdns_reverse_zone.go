@@ -2,7 +2,9 @@ package powerdns
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -16,12 +18,13 @@ func resourcePDNSReverseZone() *schema.Resource {
 		ReadContext:   resourcePDNSReverseZoneRead,
 		UpdateContext: resourcePDNSReverseZoneUpdate,
 		DeleteContext: resourcePDNSReverseZoneDelete,
+		CustomizeDiff: resourcePDNSReverseZoneCustomizeDiff,
 
 		Importer: &schema.ResourceImporter{
 			StateContext: resourcePDNSReverseZoneImport,
 		},
 
-		Schema: map[string]*schema.Schema{
+		Schema: reverseZoneSchema(map[string]*schema.Schema{
 			"cidr": {
 				Type:         schema.TypeString,
 				Required:     true,
@@ -29,31 +32,80 @@ func resourcePDNSReverseZone() *schema.Resource {
 				ValidateFunc: ValidateCIDR,
 				Description:  "The CIDR block for the reverse zone (e.g., '172.16.0.0/16')",
 			},
-			"kind": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.StringInSlice([]string{"Master", "Slave"}, false),
-				Description:  "The kind of zone (Master or Slave)",
-			},
-			"nameservers": {
-				Type:     schema.TypeList,
-				Required: true,
-				Elem: &schema.Schema{
-					Type:         schema.TypeString,
-					ValidateFunc: ValidateFQDN,
-				},
-				Description: "List of nameservers for this zone. Each nameserver must be a fully qualified domain name ending with a trailing dot.",
-			},
 			"name": {
 				Type:        schema.TypeString,
 				Computed:    true,
 				Description: "The computed zone name (e.g., '16.172.in-addr.arpa.')",
 			},
+		}),
+	}
+}
+
+// reverseZoneSchema adds the zone settings shared by powerdns_reverse_zone
+// and powerdns_reverse_zones to attributes.
+func reverseZoneSchema(attributes map[string]*schema.Schema) map[string]*schema.Schema {
+	settings := map[string]*schema.Schema{
+		"kind": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice(reverseZoneKinds, true),
+			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+				return strings.EqualFold(old, new)
+			},
+			Description: "The kind of zone (Native, Master, Slave, Producer or Consumer)",
+		},
+		"nameservers": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: ValidateFQDN,
+			},
+			Description: "List of nameservers for this zone. Each nameserver must be a fully qualified domain name ending with a trailing dot. Required by PowerDNS for every kind except Slave and Consumer.",
+		},
+		"nameserver_ttl": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      3600,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "The TTL of the NS RRset at the zone apex.",
 		},
+		// account and soa_edit_api are computed rather than defaulted, so
+		// reverse zones created before they were supported keep the
+		// values PowerDNS gave them without a diff.
+		"account": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringLenBetween(0, 40),
+			Description:  "The account that owns the zone.",
+		},
+		"catalog": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: ValidateZoneName,
+			Description:  "The catalog zone this zone is a member of.",
+		},
+		"masters": zoneMastersSchema(),
+		"soa_edit_api": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "The SOA-EDIT-API setting of the zone.",
+		},
+		"force_destroy":       zoneForceDestroySchema(),
+		"deletion_protection": zoneDeletionProtectionSchema(),
 	}
+	for k, v := range settings {
+		attributes[k] = v
+	}
+	return attributes
 }
 
+// reverseZoneKinds are the zone kinds PowerDNS supports.
+var reverseZoneKinds = []string{"Native", "Master", "Slave", "Producer", "Consumer"}
+
 func expandStringList(configured []interface{}) []string {
 	vs := make([]string, 0, len(configured))
 	for _, v := range configured {
@@ -75,20 +127,58 @@ func resourcePDNSReverseZoneCreate(ctx context.Context, d *schema.ResourceData,
 	}
 	tflog.Info(ctx, "Generated reverse zone name", map[string]any{"zone": zoneName})
 
+	createdName, err := createReverseZone(ctx, client.PDNS, zoneName, d)
+	if createdName != "" {
+		d.SetId(createdName)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Info(ctx, "Created reverse zone", map[string]any{"id": createdName})
+	return resourcePDNSReverseZoneRead(ctx, d, meta)
+}
+
+// createReverseZone creates zoneName with the zone settings in d and returns
+// the name PowerDNS gave it. The name is also returned when only setting the
+// nameservers fails, so the caller can still track the zone.
+func createReverseZone(ctx context.Context, client *PowerDNSClient, zoneName string, d *schema.ResourceData) (string, error) {
+	nameservers := expandStringList(d.Get("nameservers").([]interface{}))
 	zone := ZoneInfo{
 		Name:        zoneName,
 		Kind:        d.Get("kind").(string),
-		Nameservers: expandStringList(d.Get("nameservers").([]interface{})),
+		Catalog:     d.Get("catalog").(string),
+		Account:     d.Get("account").(string),
+		Nameservers: nameservers,
+		SoaEditAPI:  d.Get("soa_edit_api").(string),
 	}
 
-	createdZone, err := client.PDNS.CreateZone(ctx, zone)
+	if masters := expandStringSet(d.Get("masters").(*schema.Set)); len(masters) != 0 {
+		if strings.EqualFold(zone.Kind, "Slave") {
+			zone.Masters = masters
+		} else {
+			return "", fmt.Errorf("masters attribute is supported only for Slave kind")
+		}
+	}
+
+	if zone.Catalog != "" {
+		if err := checkZoneCatalog(ctx, client, zone.Catalog); err != nil {
+			return "", err
+		}
+	}
+
+	createdZone, err := client.CreateZone(ctx, zone)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("failed to create reverse zone: %w", err))
+		return "", fmt.Errorf("failed to create reverse zone: %w", err)
 	}
 
-	d.SetId(createdZone.Name)
-	tflog.Info(ctx, "Created reverse zone", map[string]any{"id": createdZone.Name})
-	return resourcePDNSReverseZoneRead(ctx, d, meta)
+	// PowerDNS creates the NS RRset with its default TTL.
+	if len(nameservers) > 0 {
+		if err := replaceZoneNameservers(ctx, client, createdZone.Name, nameservers, d.Get("nameserver_ttl").(int)); err != nil {
+			return createdZone.Name, err
+		}
+	}
+	return createdZone.Name, nil
 }
 
 func resourcePDNSReverseZoneRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -98,43 +188,111 @@ func resourcePDNSReverseZoneRead(ctx context.Context, d *schema.ResourceData, me
 	tflog.SetField(ctx, "zone", zoneName)
 	tflog.Debug(ctx, "Reading reverse zone")
 
-	zone, err := client.PDNS.GetZone(ctx, zoneName)
+	settings, err := readReverseZoneSettings(ctx, client.PDNS, zoneName)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("couldn't fetch zone: %w", err))
+		return diag.FromErr(err)
 	}
 
 	// If zone doesn't exist, clear state
-	if zone.Name == "" {
+	if settings == nil {
 		tflog.Warn(ctx, "Zone not found; removing from state")
 		d.SetId("")
 		return nil
 	}
 
-	tflog.Info(ctx, "Found reverse zone", map[string]any{"zone": zone.Name, "kind": zone.Kind})
+	tflog.Info(ctx, "Found reverse zone", map[string]any{"zone": settings.Name, "kind": settings.Kind})
 
-	if err := d.Set("name", zone.Name); err != nil {
+	if err := d.Set("name", settings.Name); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting name: %w", err))
 	}
-	if err := d.Set("kind", zone.Kind); err != nil {
-		return diag.FromErr(fmt.Errorf("error setting kind: %w", err))
+	if err := settings.setResourceData(d); err != nil {
+		return diag.FromErr(err)
 	}
 
-	// Read nameservers from NS records
-	nameservers, err := client.PDNS.ListRecordsInRRSet(ctx, zoneName, zoneName, "NS")
+	return nil
+}
+
+// reverseZoneSettings are the settings of a reverse zone as PowerDNS reports
+// them.
+type reverseZoneSettings struct {
+	Name        string
+	Kind        string
+	Account     string
+	Catalog     string
+	SoaEditAPI  string
+	Masters     []string
+	Nameservers []string
+	// NameserverTTL is the TTL of the NS RRset, or 0 when the zone has none.
+	NameserverTTL int
+}
+
+// readReverseZoneSettings fetches the settings of zoneName. It returns nil
+// when the zone does not exist.
+func readReverseZoneSettings(ctx context.Context, client *PowerDNSClient, zoneName string) (*reverseZoneSettings, error) {
+	zone, err := client.GetZone(ctx, zoneName)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("couldn't fetch zone %s nameservers from PowerDNS: %w", zoneName, err))
+		return nil, fmt.Errorf("couldn't fetch zone: %w", err)
 	}
 
-	var zoneNameservers []string
-	for _, ns := range nameservers {
-		zoneNameservers = append(zoneNameservers, ns.Content)
+	settings := &reverseZoneSettings{
+		Name:       zone.Name,
+		Kind:       zone.Kind,
+		Account:    zone.Account,
+		Catalog:    zone.Catalog,
+		SoaEditAPI: zone.SoaEditAPI,
+	}
+	if strings.EqualFold(zone.Kind, "Slave") {
+		settings.Masters = zone.Masters
 	}
 
-	if err := d.Set("nameservers", zoneNameservers); err != nil {
-		return diag.FromErr(fmt.Errorf("error setting nameservers: %w", err))
+	// Read nameservers from NS records
+	nameservers, err := client.ListRecordsInRRSet(ctx, zoneName, zoneName, "NS")
+	if err != nil {
+		return nil, fmt.Errorf("couldn't fetch zone %s nameservers from PowerDNS: %w", zoneName, err)
+	}
+	for _, ns := range nameservers {
+		settings.Nameservers = append(settings.Nameservers, ns.Content)
+	}
+	if len(nameservers) > 0 {
+		settings.NameserverTTL = nameservers[0].TTL
 	}
 
-	return nil
+	return settings, nil
+}
+
+// setResourceData stores the settings in the attributes of d. masters is
+// only set for Slave zones and nameserver_ttl only when the zone has an NS
+// RRset, so the configured values are kept otherwise.
+func (s *reverseZoneSettings) setResourceData(d *schema.ResourceData) error {
+	if err := d.Set("kind", s.Kind); err != nil {
+		return fmt.Errorf("error setting kind: %w", err)
+	}
+	if err := d.Set("account", s.Account); err != nil {
+		return fmt.Errorf("error setting account: %w", err)
+	}
+	if err := d.Set("catalog", s.Catalog); err != nil {
+		return fmt.Errorf("error setting catalog: %w", err)
+	}
+	if err := d.Set("soa_edit_api", s.SoaEditAPI); err != nil {
+		return fmt.Errorf("error setting soa_edit_api: %w", err)
+	}
+	if strings.EqualFold(s.Kind, "Slave") {
+		if err := d.Set("masters", s.Masters); err != nil {
+			return fmt.Errorf("error setting masters: %w", err)
+		}
+	}
+	if err := d.Set("nameservers", s.Nameservers); err != nil {
+		return fmt.Errorf("error setting nameservers: %w", err)
+	}
+	if s.NameserverTTL > 0 {
+		if err := d.Set("nameserver_ttl", s.NameserverTTL); err != nil {
+			return fmt.Errorf("error setting nameserver_ttl: %w", err)
+		}
+	}
+	return setZoneDeletionDefaults(d)
 }
 
 func resourcePDNSReverseZoneUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -142,53 +300,118 @@ func resourcePDNSReverseZoneUpdate(ctx context.Context, d *schema.ResourceData,
 	zoneName := d.Id()
 
 	tflog.SetField(ctx, "zone", zoneName)
-	if d.HasChange("nameservers") {
-		tflog.Debug(ctx, "Updating nameservers for reverse zone")
+	tflog.Debug(ctx, "Updating reverse zone")
 
-		zone, err := client.PDNS.GetZone(ctx, zoneName)
-		if err != nil {
-			return diag.FromErr(fmt.Errorf("couldn't fetch zone: %w", err))
-		}
+	diags, err := updateReverseZone(ctx, client.PDNS, zoneName, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return append(diags, resourcePDNSReverseZoneRead(ctx, d, meta)...)
+}
 
-		// Update nameservers in zone object
-		zone.Nameservers = expandStringList(d.Get("nameservers").([]interface{}))
+// resourcePDNSReverseZoneCustomizeDiff checks the catalog, and that masters
+// fits the kind.
+func resourcePDNSReverseZoneCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if err := customizeZoneCatalogDiff(ctx, d, meta); err != nil {
+		return err
+	}
+	return customizeZoneKindDiff(d, fmt.Sprintf("zone %s", d.Id()))
+}
 
-		// Build update request
+// updateReverseZone applies the changed zone settings in d to zoneName. When
+// the kind changes, it returns warnings for settings that no longer fit it.
+func updateReverseZone(ctx context.Context, client *PowerDNSClient, zoneName string, d *schema.ResourceData) (diag.Diagnostics, error) {
+	var diags diag.Diagnostics
+	if d.HasChanges("kind", "account", "catalog", "soa_edit_api", "masters") {
+		soaEditAPI := d.Get("soa_edit_api").(string)
 		zoneInfo := ZoneInfoUpd{
 			Name:       zoneName,
-			Kind:       zone.Kind,
-			Account:    zone.Account,
-			SoaEditAPI: zone.SoaEditAPI,
+			Kind:       d.Get("kind").(string),
+			Catalog:    d.Get("catalog").(string),
+			Account:    d.Get("account").(string),
+			SoaEditAPI: &soaEditAPI,
+			Masters:    expandStringSet(d.Get("masters").(*schema.Set)),
+		}
+
+		// As for powerdns_zone, the primaries of a zone that stops being a
+		// secondary are removed explicitly.
+		oldKind, _ := d.GetChange("kind")
+		leavingSlave := d.HasChange("kind") && strings.EqualFold(oldKind.(string), "Slave")
+		if leavingSlave {
+			zoneInfo.Masters = []string{}
+		}
+		// A configured soa_edit_api is rejected at plan time for a zone
+		// changed to Slave, so a value left in state is stale.
+		if d.HasChange("kind") && strings.EqualFold(zoneInfo.Kind, "Slave") {
+			soaEditAPI = ""
 		}
 
-		if err := client.PDNS.UpdateZone(ctx, zoneName, zoneInfo); err != nil {
-			return diag.FromErr(fmt.Errorf("error updating zone: %w", err))
+		if d.HasChange("catalog") && zoneInfo.Catalog != "" {
+			if err := checkZoneCatalog(ctx, client, zoneInfo.Catalog); err != nil {
+				return nil, err
+			}
 		}
 
-		// Update NS records to reflect nameserver list
-		rrSet := ResourceRecordSet{
-			Name:       zoneName,
-			Type:       "NS",
-			TTL:        3600,
-			ChangeType: "REPLACE",
-			Records:    make([]Record, len(zone.Nameservers)),
+		if err := client.UpdateZone(ctx, zoneName, zoneInfo); err != nil {
+			return nil, fmt.Errorf("error updating zone: %w", err)
 		}
 
-		for i, ns := range zone.Nameservers {
-			rrSet.Records[i] = Record{
-				Content: ns,
-				TTL:     3600,
+		if d.HasChange("kind") {
+			tflog.Info(ctx, "Changed reverse zone kind", map[string]any{"zone": zoneName, "from": oldKind, "to": zoneInfo.Kind, "cleared_masters": leavingSlave})
+
+			metadata, err := client.ListZoneMetadata(ctx, zoneName)
+			if err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  "Couldn't check zone metadata after changing the zone kind",
+					Detail:   err.Error(),
+				})
+			} else {
+				diags = append(diags, zoneKindWarnings(zoneName, zoneInfo.Kind, metadata)...)
 			}
 		}
+	}
 
-		if _, err := client.PDNS.ReplaceRecordSet(ctx, zoneName, rrSet); err != nil {
-			return diag.FromErr(fmt.Errorf("error updating nameserver records: %w", err))
+	if d.HasChanges("nameservers", "nameserver_ttl") {
+		nameservers := expandStringList(d.Get("nameservers").([]interface{}))
+		if err := replaceZoneNameservers(ctx, client, zoneName, nameservers, d.Get("nameserver_ttl").(int)); err != nil {
+			return nil, err
 		}
+		tflog.Info(ctx, "Updated nameservers for reverse zone", map[string]any{"zone": zoneName})
+	}
 
-		tflog.Info(ctx, "Updated nameservers for reverse zone")
+	return diags, nil
+}
+
+// replaceZoneNameservers replaces the NS RRset at the apex of zoneName. An
+// empty list of nameservers deletes it.
+func replaceZoneNameservers(ctx context.Context, client *PowerDNSClient, zoneName string, nameservers []string, ttl int) error {
+	if len(nameservers) == 0 {
+		if err := client.DeleteRecordSet(ctx, zoneName, zoneName, "NS"); err != nil {
+			return fmt.Errorf("error deleting nameserver records: %w", err)
+		}
+		return nil
 	}
 
-	return resourcePDNSReverseZoneRead(ctx, d, meta)
+	rrSet := ResourceRecordSet{
+		Name:       zoneName,
+		Type:       "NS",
+		TTL:        ttl,
+		ChangeType: "REPLACE",
+		Records:    make([]Record, len(nameservers)),
+	}
+	for i, ns := range nameservers {
+		rrSet.Records[i] = Record{
+			Content: ns,
+			TTL:     ttl,
+		}
+	}
+
+	if _, err := client.ReplaceRecordSet(ctx, zoneName, rrSet); err != nil {
+		return fmt.Errorf("error updating nameserver records: %w", err)
+	}
+	return nil
 }
 
 func resourcePDNSReverseZoneDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -198,6 +421,10 @@ func resourcePDNSReverseZoneDelete(ctx context.Context, d *schema.ResourceData,
 	tflog.SetField(ctx, "zone", zoneName)
 	tflog.Debug(ctx, "Deleting reverse zone")
 
+	if err := checkZoneDeletion(ctx, client.PDNS, zoneName, d); err != nil {
+		return diag.FromErr(err)
+	}
+
 	if err := client.PDNS.DeleteZone(ctx, zoneName); err != nil {
 		return diag.FromErr(fmt.Errorf("error deleting zone: %w", err))
 	}
@@ -206,36 +433,28 @@ func resourcePDNSReverseZoneDelete(ctx context.Context, d *schema.ResourceData,
 	return nil
 }
 
+// resourcePDNSReverseZoneImport accepts the zone name or the CIDR of the
+// reverse zone; the remaining attributes are filled in by Read.
 func resourcePDNSReverseZoneImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	client := meta.(*ProviderClients)
-
 	zoneName := d.Id()
 	tflog.Info(ctx, "Importing reverse zone", map[string]any{"zone": zoneName})
 
-	cidr, err := ParseReverseZoneName(zoneName)
-	if err != nil {
-		return nil, err
+	if strings.Contains(zoneName, "/") && !strings.HasSuffix(zoneName, ".arpa.") {
+		var err error
+		if zoneName, err = GetReverseZoneName(zoneName); err != nil {
+			return nil, fmt.Errorf("invalid import ID %q, expected a reverse zone name or a CIDR: %w", d.Id(), err)
+		}
 	}
 
-	zone, err := client.PDNS.GetZone(ctx, zoneName)
+	cidr, err := ParseReverseZoneName(zoneName)
 	if err != nil {
-		return nil, fmt.Errorf("error getting zone: %w", err)
+		return nil, err
 	}
 
-	// Populate attributes
-	if err := d.Set("name", zoneName); err != nil {
-		return nil, fmt.Errorf("error setting name: %w", err)
-	}
 	if err := d.Set("cidr", cidr); err != nil {
 		return nil, fmt.Errorf("error setting cidr: %w", err)
 	}
-	if err := d.Set("nameservers", zone.Nameservers); err != nil {
-		return nil, fmt.Errorf("error setting nameservers: %w", err)
-	}
-	// Ensure kind is set to avoid diffs after import
-	if err := d.Set("kind", zone.Kind); err != nil {
-		return nil, fmt.Errorf("error setting kind: %w", err)
-	}
+	d.SetId(zoneName)
 
 	return []*schema.ResourceData{d}, nil
 }
@@ -0,0 +1,273 @@
+package powerdns
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourcePDNSReverseZoneDelegation manages the records in a /24 reverse zone
+// that delegate a smaller block to an RFC 2317 classless zone: one CNAME per
+// address pointing into the classless zone, and optionally the NS RRset of
+// the classless zone name.
+func resourcePDNSReverseZoneDelegation() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePDNSReverseZoneDelegationUpsert,
+		ReadContext:   resourcePDNSReverseZoneDelegationRead,
+		UpdateContext: resourcePDNSReverseZoneDelegationUpsert,
+		DeleteContext: resourcePDNSReverseZoneDelegationDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcePDNSReverseZoneDelegationImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cidr": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: ValidateClasslessCIDR,
+				Description:  "The IPv4 block to delegate, between /25 and /32 (e.g., '192.0.2.0/26').",
+			},
+			"ttl": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The TTL of the delegation records.",
+			},
+			"nameservers": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: ValidateFQDN,
+				},
+				Description: "Nameservers of the classless zone. If set, an NS RRset for the classless zone name is created in the parent zone.",
+			},
+			"parent_zone": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The /24 reverse zone holding the delegation records (e.g., '2.0.192.in-addr.arpa.').",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The RFC 2317 classless zone name (e.g., '0/26.2.0.192.in-addr.arpa.').",
+			},
+		},
+	}
+}
+
+// classlessDelegation describes the records that delegate a classless block.
+type classlessDelegation struct {
+	parentZone string
+	zoneName   string
+	// cnames maps each owner name in the parent zone to its CNAME target.
+	cnames map[string]string
+}
+
+// newClasslessDelegation computes the parent zone, the classless zone name
+// and the CNAMEs that delegate the addresses of an IPv4 block below /24.
+func newClasslessDelegation(cidr string) (classlessDelegation, error) {
+	if _, errs := ValidateClasslessCIDR(cidr, "cidr"); len(errs) > 0 {
+		return classlessDelegation{}, errs[0]
+	}
+
+	_, block, _ := net.ParseCIDR(cidr)
+	ip := block.IP.To4()
+	ones, _ := block.Mask.Size()
+
+	zoneName, err := GetReverseZoneName(block.String())
+	if err != nil {
+		return classlessDelegation{}, err
+	}
+	parentZone := fmt.Sprintf("%d.%d.%d.in-addr.arpa.", ip[2], ip[1], ip[0])
+
+	size := 1 << (32 - ones)
+	cnames := make(map[string]string, size)
+	for i := 0; i < size; i++ {
+		last := int(ip[3]) + i
+		cnames[fmt.Sprintf("%d.%s", last, parentZone)] = fmt.Sprintf("%d.%s", last, zoneName)
+	}
+
+	return classlessDelegation{
+		parentZone: parentZone,
+		zoneName:   zoneName,
+		cnames:     cnames,
+	}, nil
+}
+
+// rrSets returns the changes that create the delegation, or with changeType
+// DELETE the ones that remove it. Without nameservers the NS RRset is deleted.
+func (cd classlessDelegation) rrSets(changeType string, ttl int, nameservers []string) []ResourceRecordSet {
+	owners := make([]string, 0, len(cd.cnames))
+	for owner := range cd.cnames {
+		owners = append(owners, owner)
+	}
+	sort.Strings(owners)
+
+	rrSets := make([]ResourceRecordSet, 0, len(cd.cnames)+1)
+	for _, owner := range owners {
+		rrSet := ResourceRecordSet{Name: owner, Type: "CNAME", ChangeType: changeType}
+		if changeType == "REPLACE" {
+			rrSet.TTL = ttl
+			rrSet.Records = []Record{{Content: cd.cnames[owner], TTL: ttl}}
+		}
+		rrSets = append(rrSets, rrSet)
+	}
+
+	ns := ResourceRecordSet{Name: cd.zoneName, Type: "NS", ChangeType: "DELETE"}
+	if changeType == "REPLACE" && len(nameservers) > 0 {
+		ns.ChangeType = "REPLACE"
+		ns.TTL = ttl
+		for _, nameserver := range nameservers {
+			ns.Records = append(ns.Records, Record{Content: nameserver, TTL: ttl})
+		}
+	}
+	rrSets = append(rrSets, ns)
+
+	return rrSets
+}
+
+func resourcePDNSReverseZoneDelegationUpsert(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderClients)
+
+	cidr := d.Get("cidr").(string)
+	tflog.SetField(ctx, "cidr", cidr)
+	tflog.Debug(ctx, "Creating classless reverse delegation")
+
+	delegation, err := newClasslessDelegation(cidr)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to determine delegation records: %w", err))
+	}
+
+	ttl := d.Get("ttl").(int)
+	nameservers := expandStringList(d.Get("nameservers").([]interface{}))
+	if err := client.PDNS.PatchRecordSets(ctx, delegation.parentZone, delegation.rrSets("REPLACE", ttl, nameservers)); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to create classless reverse delegation: %w", err))
+	}
+
+	d.SetId(delegation.zoneName)
+	tflog.Info(ctx, "Created classless reverse delegation", map[string]any{
+		"id":          d.Id(),
+		"parent_zone": delegation.parentZone,
+	})
+	return resourcePDNSReverseZoneDelegationRead(ctx, d, meta)
+}
+
+func resourcePDNSReverseZoneDelegationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderClients)
+
+	cidr := d.Get("cidr").(string)
+	tflog.SetField(ctx, "cidr", cidr)
+	tflog.Debug(ctx, "Reading classless reverse delegation")
+
+	delegation, err := newClasslessDelegation(cidr)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to determine delegation records: %w", err))
+	}
+
+	records, err := client.PDNS.ListRecords(ctx, delegation.parentZone)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't fetch records of zone %s: %w", delegation.parentZone, err))
+	}
+
+	found := 0
+	ttl := 0
+	var nameservers []string
+	for _, record := range records {
+		switch {
+		case strings.EqualFold(record.Type, "CNAME"):
+			target, ok := delegation.cnames[strings.ToLower(record.Name)]
+			if ok && strings.EqualFold(record.Content, target) {
+				found++
+				ttl = record.TTL
+			}
+		case strings.EqualFold(record.Type, "NS") && strings.EqualFold(record.Name, delegation.zoneName):
+			nameservers = append(nameservers, record.Content)
+		}
+	}
+
+	// A partly removed delegation is dropped from state, so the next apply
+	// writes all of its records again.
+	if found != len(delegation.cnames) {
+		tflog.Warn(ctx, "Classless reverse delegation missing or incomplete; removing from state", map[string]any{
+			"found":    found,
+			"expected": len(delegation.cnames),
+		})
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("ttl", ttl); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting ttl: %w", err))
+	}
+	if err := d.Set("nameservers", nameservers); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting nameservers: %w", err))
+	}
+	if err := d.Set("parent_zone", delegation.parentZone); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting parent_zone: %w", err))
+	}
+	if err := d.Set("name", delegation.zoneName); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting name: %w", err))
+	}
+
+	return nil
+}
+
+func resourcePDNSReverseZoneDelegationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderClients)
+
+	cidr := d.Get("cidr").(string)
+	tflog.SetField(ctx, "cidr", cidr)
+	tflog.Debug(ctx, "Deleting classless reverse delegation")
+
+	delegation, err := newClasslessDelegation(cidr)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to determine delegation records: %w", err))
+	}
+
+	if err := client.PDNS.PatchRecordSets(ctx, delegation.parentZone, delegation.rrSets("DELETE", 0, nil)); err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting classless reverse delegation: %w", err))
+	}
+
+	tflog.Info(ctx, "Deleted classless reverse delegation")
+	return nil
+}
+
+func resourcePDNSReverseZoneDelegationImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	tflog.Info(ctx, "Importing classless reverse delegation", map[string]any{"id": d.Id()})
+
+	// Accept both the CIDR and the classless zone name.
+	cidr := d.Id()
+	if IsClasslessReverseZone(cidr) {
+		var err error
+		if cidr, err = ParseReverseZoneName(cidr); err != nil {
+			return nil, err
+		}
+	}
+
+	delegation, err := newClasslessDelegation(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid import ID %q, expected a CIDR such as 192.0.2.0/26 or a zone name such as 0/26.2.0.192.in-addr.arpa.: %w", d.Id(), err)
+	}
+
+	// Store the block in its canonical form, whatever address it was given with.
+	cidr, err = ParseReverseZoneName(delegation.zoneName)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.Set("cidr", cidr); err != nil {
+		return nil, fmt.Errorf("error setting cidr: %w", err)
+	}
+	d.SetId(delegation.zoneName)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,197 @@
+package powerdns
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClasslessDelegation(t *testing.T) {
+	delegation, err := newClasslessDelegation("192.0.2.64/30")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "2.0.192.in-addr.arpa.", delegation.parentZone)
+	assert.Equal(t, "64/30.2.0.192.in-addr.arpa.", delegation.zoneName)
+	assert.Equal(t, map[string]string{
+		"64.2.0.192.in-addr.arpa.": "64.64/30.2.0.192.in-addr.arpa.",
+		"65.2.0.192.in-addr.arpa.": "65.64/30.2.0.192.in-addr.arpa.",
+		"66.2.0.192.in-addr.arpa.": "66.64/30.2.0.192.in-addr.arpa.",
+		"67.2.0.192.in-addr.arpa.": "67.64/30.2.0.192.in-addr.arpa.",
+	}, delegation.cnames)
+}
+
+func TestNewClasslessDelegationInvalid(t *testing.T) {
+	for _, cidr := range []string{"192.0.2.0/24", "2001:db8::/64", "not-a-cidr"} {
+		_, err := newClasslessDelegation(cidr)
+		assert.Error(t, err, cidr)
+	}
+}
+
+func TestClasslessDelegationRRSets(t *testing.T) {
+	delegation, err := newClasslessDelegation("192.0.2.128/31")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	rrSets := delegation.rrSets("REPLACE", 3600, []string{"ns1.customer.example."})
+	if assert.Len(t, rrSets, 3) {
+		for _, rrSet := range rrSets[:2] {
+			assert.Equal(t, "CNAME", rrSet.Type)
+			assert.Equal(t, "REPLACE", rrSet.ChangeType)
+			assert.Equal(t, 3600, rrSet.TTL)
+			assert.Len(t, rrSet.Records, 1)
+		}
+		assert.Equal(t, ResourceRecordSet{
+			Name:       "128/31.2.0.192.in-addr.arpa.",
+			Type:       "NS",
+			ChangeType: "REPLACE",
+			TTL:        3600,
+			Records:    []Record{{Content: "ns1.customer.example.", TTL: 3600}},
+		}, rrSets[2])
+	}
+
+	// Without nameservers the NS RRset is removed, so dropping them on
+	// update cleans up the parent zone.
+	rrSets = delegation.rrSets("REPLACE", 3600, nil)
+	assert.Equal(t, "DELETE", rrSets[2].ChangeType)
+
+	for _, rrSet := range delegation.rrSets("DELETE", 0, nil) {
+		assert.Equal(t, "DELETE", rrSet.ChangeType)
+		assert.Empty(t, rrSet.Records)
+	}
+}
+
+func TestAccPowerDNSReverseZoneDelegation_Basic(t *testing.T) {
+	resourceName := "powerdns_reverse_zone_delegation.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerDNSReverseZoneDelegationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPowerDNSReverseZoneDelegationConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPDNSZoneExists("powerdns_reverse_zone.classless"),
+					resource.TestCheckResourceAttr("powerdns_reverse_zone.classless", "name", "64/26.2.0.192.in-addr.arpa."),
+					testAccCheckPowerDNSReverseZoneDelegationExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", "64/26.2.0.192.in-addr.arpa."),
+					resource.TestCheckResourceAttr(resourceName, "parent_zone", "2.0.192.in-addr.arpa."),
+					resource.TestCheckResourceAttr(resourceName, "nameservers.#", "1"),
+					resource.TestCheckResourceAttr("powerdns_ptr_record.classless", "ip_address", "192.0.2.70"),
+				),
+			},
+			{
+				Config: testAccPowerDNSReverseZoneDelegationConfigUpdated,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPowerDNSReverseZoneDelegationExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "ttl", "600"),
+					resource.TestCheckResourceAttr(resourceName, "nameservers.#", "0"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportStateId:     "192.0.2.64/26",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckPowerDNSReverseZoneDelegationExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		delegation, err := newClasslessDelegation(rs.Primary.Attributes["cidr"])
+		if err != nil {
+			return err
+		}
+
+		client := testAccProvider.Meta().(*ProviderClients).PDNS
+		for owner := range delegation.cnames {
+			exists, err := client.RecordExists(context.Background(), delegation.parentZone, owner, "CNAME")
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return fmt.Errorf("Delegation CNAME does not exist: %s", owner)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckPowerDNSReverseZoneDelegationDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "powerdns_reverse_zone_delegation" {
+			continue
+		}
+
+		delegation, err := newClasslessDelegation(rs.Primary.Attributes["cidr"])
+		if err != nil {
+			return err
+		}
+
+		client := testAccProvider.Meta().(*ProviderClients).PDNS
+		for owner := range delegation.cnames {
+			exists, err := client.RecordExists(context.Background(), delegation.parentZone, owner, "CNAME")
+			if err != nil {
+				return fmt.Errorf("Error checking if delegation still exists: %#v", rs.Primary.ID)
+			}
+			if exists {
+				return fmt.Errorf("Delegation CNAME still exists: %s", owner)
+			}
+		}
+	}
+	return nil
+}
+
+const testAccPowerDNSReverseZoneDelegationZones = `
+resource "powerdns_reverse_zone" "parent" {
+  cidr        = "192.0.2.0/24"
+  kind        = "Master"
+  nameservers = ["ns1.example.com."]
+}
+
+resource "powerdns_reverse_zone" "classless" {
+  cidr        = "192.0.2.64/26"
+  kind        = "Master"
+  nameservers = ["ns1.example.com."]
+}
+
+resource "powerdns_ptr_record" "classless" {
+  ip_address   = "192.0.2.70"
+  hostname     = "host.example.com."
+  ttl          = 300
+  reverse_zone = powerdns_reverse_zone.classless.name
+}
+`
+
+const testAccPowerDNSReverseZoneDelegationConfig = testAccPowerDNSReverseZoneDelegationZones + `
+resource "powerdns_reverse_zone_delegation" "test" {
+  cidr        = "192.0.2.64/26"
+  ttl         = 3600
+  nameservers = ["ns1.example.com."]
+
+  depends_on = [powerdns_reverse_zone.parent]
+}
+`
+
+const testAccPowerDNSReverseZoneDelegationConfigUpdated = testAccPowerDNSReverseZoneDelegationZones + `
+resource "powerdns_reverse_zone_delegation" "test" {
+  cidr = "192.0.2.64/26"
+  ttl  = 600
+
+  depends_on = [powerdns_reverse_zone.parent]
+}
+`
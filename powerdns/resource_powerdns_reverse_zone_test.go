@@ -1,10 +1,18 @@
 package powerdns
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
 	"regexp"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccPowerDNSReverseZone_CIDR(t *testing.T) {
@@ -100,6 +108,230 @@ func TestAccPowerDNSReverseZone_InvalidIPv6Prefix(t *testing.T) {
 	})
 }
 
+func TestAccPowerDNSReverseZone_Attributes(t *testing.T) {
+	resourceName := "powerdns_reverse_zone.test_attrs"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPowerDNSReverseZoneConfig_Attributes("Native", "ops", 7200),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPDNSZoneExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", "42.18.198.in-addr.arpa."),
+					resource.TestCheckResourceAttr(resourceName, "kind", "Native"),
+					resource.TestCheckResourceAttr(resourceName, "account", "ops"),
+					resource.TestCheckResourceAttr(resourceName, "soa_edit_api", "INCEPTION-INCREMENT"),
+					resource.TestCheckResourceAttr(resourceName, "nameserver_ttl", "7200"),
+				),
+			},
+			{
+				// kind, account and the nameserver TTL are updated in place.
+				Config: testAccPowerDNSReverseZoneConfig_Attributes("Master", "netops", 300),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPDNSZoneExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "kind", "Master"),
+					resource.TestCheckResourceAttr(resourceName, "account", "netops"),
+					resource.TestCheckResourceAttr(resourceName, "nameserver_ttl", "300"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      resourceName,
+				ImportStateId:     "198.18.42.0/24",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccPowerDNSReverseZone_Slave(t *testing.T) {
+	resourceName := "powerdns_reverse_zone.test_slave"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPowerDNSReverseZoneConfig_Slave,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPDNSZoneExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "kind", "Slave"),
+					resource.TestCheckResourceAttr(resourceName, "masters.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPowerDNSReverseZone_MastersRequireSlave(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccPowerDNSReverseZoneConfig_MastersOnMaster,
+				ExpectError: regexp.MustCompile("masters attribute is supported only for Slave kind"),
+			},
+		},
+	})
+}
+
+func TestAccPowerDNSReverseZone_KindTransitions(t *testing.T) {
+	resourceName := "powerdns_reverse_zone.test_kind"
+
+	checkMasters := func(expected int) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			client := testAccProvider.Meta().(*ProviderClients).PDNS
+			zone, err := client.GetZone(context.Background(), "45.18.198.in-addr.arpa.")
+			if err != nil {
+				return err
+			}
+			if len(zone.Masters) != expected {
+				return fmt.Errorf("expected %d masters, got %v", expected, zone.Masters)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPowerDNSReverseZoneConfig_Kind("Master", ""),
+				Check:  resource.TestCheckResourceAttr(resourceName, "kind", "Master"),
+			},
+			{
+				Config:      testAccPowerDNSReverseZoneConfig_Kind("Slave", ""),
+				ExpectError: regexp.MustCompile(`masters must be set when changing the kind of zone 45\.18\.198\.in-addr\.arpa\. to Slave`),
+			},
+			{
+				Config: testAccPowerDNSReverseZoneConfig_Kind("Slave", `masters = ["192.0.2.53"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "kind", "Slave"),
+					checkMasters(1),
+				),
+			},
+			{
+				// Leaving Slave removes the primaries on the server.
+				Config: testAccPowerDNSReverseZoneConfig_Kind("Master", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "kind", "Master"),
+					resource.TestCheckResourceAttr(resourceName, "masters.#", "0"),
+					checkMasters(0),
+				),
+			},
+		},
+	})
+}
+
+func TestUpdateReverseZoneClearsMastersWhenLeavingSlave(t *testing.T) {
+	var update map[string]interface{}
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		switch r.Method {
+		case http.MethodPut:
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+			return jsonResponse(http.StatusNoContent, ``), nil
+		default:
+			return jsonResponse(http.StatusOK, `[]`), nil
+		}
+	})
+
+	state := &terraform.InstanceState{
+		ID: "2.0.192.in-addr.arpa.",
+		Attributes: map[string]string{
+			"id":        "2.0.192.in-addr.arpa.",
+			"cidr":      "192.0.2.0/24",
+			"kind":      "Slave",
+			"masters.#": "1",
+			"masters.0": "192.0.2.53",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"cidr": "192.0.2.0/24",
+		"kind": "Master",
+	})
+	sm := schema.InternalMap(resourcePDNSReverseZone().Schema)
+	diff, err := sm.Diff(context.Background(), state, config, nil, nil, true)
+	if !assert.NoError(t, err) {
+		return
+	}
+	d, err := sm.Data(state, diff)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = updateReverseZone(context.Background(), client, d.Id(), d)
+	assert.NoError(t, err)
+	assert.Equal(t, "Master", update["kind"])
+	assert.Equal(t, []interface{}{}, update["masters"])
+}
+
+func TestReverseZoneChangedToSlaveDropsSOAEditAPI(t *testing.T) {
+	plan := func(soaEditAPI cty.Value) (*schema.ResourceData, error) {
+		state := &terraform.InstanceState{
+			ID: "2.0.192.in-addr.arpa.",
+			Attributes: map[string]string{
+				"id":           "2.0.192.in-addr.arpa.",
+				"cidr":         "192.0.2.0/24",
+				"kind":         "Master",
+				"soa_edit_api": "DEFAULT",
+			},
+			RawConfig: cty.ObjectVal(map[string]cty.Value{"soa_edit_api": soaEditAPI}),
+		}
+		raw := map[string]interface{}{
+			"cidr":    "192.0.2.0/24",
+			"kind":    "Slave",
+			"masters": []interface{}{"192.0.2.53"},
+		}
+		if !soaEditAPI.IsNull() {
+			raw["soa_edit_api"] = soaEditAPI.AsString()
+		}
+		r := resourcePDNSReverseZone()
+		sm := schema.InternalMap(r.Schema)
+		diff, err := sm.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), r.CustomizeDiff, &ProviderClients{}, true)
+		if err != nil {
+			return nil, err
+		}
+		return sm.Data(state, diff)
+	}
+
+	_, err := plan(cty.StringVal("DEFAULT"))
+	assert.ErrorContains(t, err, "soa_edit_api has no effect on Slave zones")
+
+	// Unset or empty, the value read from the server is cleared in the
+	// same update that changes the kind.
+	for _, soaEditAPI := range []cty.Value{cty.NullVal(cty.String), cty.StringVal("")} {
+		d, err := plan(soaEditAPI)
+		if !assert.NoError(t, err) {
+			continue
+		}
+
+		var update map[string]interface{}
+		client := newTestClient(func(r *http.Request) (*http.Response, error) {
+			if r.Method == http.MethodPut {
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+				return jsonResponse(http.StatusNoContent, ``), nil
+			}
+			return jsonResponse(http.StatusOK, `[]`), nil
+		})
+		_, err = updateReverseZone(context.Background(), client, d.Id(), d)
+		assert.NoError(t, err)
+		assert.Equal(t, "Slave", update["kind"])
+		assert.Equal(t, "", update["soa_edit_api"], soaEditAPI.GoString())
+	}
+}
+
 func TestExpandStringList(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -186,3 +418,44 @@ resource "powerdns_reverse_zone" "test_ipv6" {
   nameservers = ["ns1.example.com."]
 }
 `
+
+func testAccPowerDNSReverseZoneConfig_Attributes(kind string, account string, nameserverTTL int) string {
+	return fmt.Sprintf(`
+resource "powerdns_reverse_zone" "test_attrs" {
+  cidr           = "198.18.42.0/24"
+  kind           = %q
+  account        = %q
+  soa_edit_api   = "INCEPTION-INCREMENT"
+  nameservers    = ["ns1.example.com.", "ns2.example.com."]
+  nameserver_ttl = %d
+}
+`, kind, account, nameserverTTL)
+}
+
+const testAccPowerDNSReverseZoneConfig_Slave = `
+resource "powerdns_reverse_zone" "test_slave" {
+  cidr    = "198.18.43.0/24"
+  kind    = "Slave"
+  masters = ["192.0.2.53", "2001:db8::53"]
+}
+`
+
+const testAccPowerDNSReverseZoneConfig_MastersOnMaster = `
+resource "powerdns_reverse_zone" "test_masters" {
+  cidr        = "198.18.44.0/24"
+  kind        = "Master"
+  nameservers = ["ns1.example.com."]
+  masters     = ["192.0.2.53"]
+}
+`
+
+func testAccPowerDNSReverseZoneConfig_Kind(kind string, extra string) string {
+	return fmt.Sprintf(`
+resource "powerdns_reverse_zone" "test_kind" {
+  cidr        = "198.18.45.0/24"
+  kind        = %q
+  nameservers = ["ns1.example.com."]
+  %s
+}
+`, kind, extra)
+}
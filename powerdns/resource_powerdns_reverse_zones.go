@@ -0,0 +1,289 @@
+package powerdns
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourcePDNSReverseZones manages the reverse zones for a block that does not
+// fall on a zone boundary, such as an IPv6 /46, which has to be served as four
+// /48 zones. Every zone gets the same settings.
+func resourcePDNSReverseZones() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePDNSReverseZonesCreate,
+		ReadContext:   resourcePDNSReverseZonesRead,
+		UpdateContext: resourcePDNSReverseZonesUpdate,
+		DeleteContext: resourcePDNSReverseZonesDelete,
+		CustomizeDiff: resourcePDNSReverseZonesCustomizeDiff,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcePDNSReverseZonesImport,
+		},
+
+		Schema: reverseZoneSchema(map[string]*schema.Schema{
+			"cidr": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: ValidateReverseZonesCIDR,
+				Description:  "The IPv4 or IPv6 block to create reverse zones for (e.g., '2001:db8::/46'). Prefixes are split on octet or nibble boundaries.",
+			},
+			"cidrs": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The blocks cidr was split into, one per reverse zone.",
+			},
+			"zone_names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The names of the reverse zones, in the order of cidrs.",
+			},
+		}),
+	}
+}
+
+// reverseZonesForCIDR splits cidr and returns the blocks and the names of
+// their reverse zones.
+func reverseZonesForCIDR(cidr string) ([]string, []string, error) {
+	blocks, err := SplitReverseZoneCIDR(cidr)
+	if err != nil {
+		return nil, nil, err
+	}
+	names := make([]string, len(blocks))
+	for i, block := range blocks {
+		if names[i], err = GetReverseZoneName(block); err != nil {
+			return nil, nil, err
+		}
+	}
+	return blocks, names, nil
+}
+
+func resourcePDNSReverseZonesCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderClients)
+
+	_, block, err := net.ParseCIDR(d.Get("cidr").(string))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("invalid cidr: %w", err))
+	}
+	ctx = tflog.SetField(ctx, "cidr", block.String())
+	tflog.Debug(ctx, "Creating reverse zones")
+
+	_, names, err := reverseZonesForCIDR(block.String())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The ID is set first, so the zones created before a failure stay in
+	// state; Read drops the missing ones and the next apply creates them.
+	d.SetId(block.String())
+	for _, name := range names {
+		if _, err := createReverseZone(ctx, client.PDNS, name, d); err != nil {
+			return diag.FromErr(fmt.Errorf("error creating zone %s: %w", name, err))
+		}
+		tflog.Info(ctx, "Created reverse zone", map[string]any{"zone": name})
+	}
+
+	return resourcePDNSReverseZonesRead(ctx, d, meta)
+}
+
+func resourcePDNSReverseZonesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderClients)
+
+	ctx = tflog.SetField(ctx, "cidr", d.Id())
+	tflog.Debug(ctx, "Reading reverse zones")
+
+	blocks, names, err := reverseZonesForCIDR(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var existing []*reverseZoneSettings
+	var existingBlocks, existingNames []string
+	for i, name := range names {
+		settings, err := readReverseZoneSettings(ctx, client.PDNS, name)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if settings == nil {
+			tflog.Warn(ctx, "Reverse zone not found", map[string]any{"zone": name})
+			continue
+		}
+		existing = append(existing, settings)
+		existingBlocks = append(existingBlocks, blocks[i])
+		existingNames = append(existingNames, settings.Name)
+	}
+
+	if len(existing) == 0 {
+		tflog.Warn(ctx, "No reverse zones found; removing from state")
+		d.SetId("")
+		return nil
+	}
+
+	// The zones are meant to share their settings. Reporting the first zone
+	// that differs from state makes a change to any one of them show up as
+	// drift.
+	settings := existing[0]
+	for _, s := range existing {
+		if !s.matches(d) {
+			settings = s
+			break
+		}
+	}
+
+	if err := d.Set("cidrs", existingBlocks); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting cidrs: %w", err))
+	}
+	if err := d.Set("zone_names", existingNames); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting zone_names: %w", err))
+	}
+	if err := settings.setResourceData(d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// matches reports whether the settings equal the ones stored in d.
+func (s *reverseZoneSettings) matches(d *schema.ResourceData) bool {
+	if !strings.EqualFold(s.Kind, d.Get("kind").(string)) ||
+		s.Account != d.Get("account").(string) ||
+		s.Catalog != d.Get("catalog").(string) ||
+		s.SoaEditAPI != d.Get("soa_edit_api").(string) {
+		return false
+	}
+	if s.NameserverTTL > 0 && s.NameserverTTL != d.Get("nameserver_ttl").(int) {
+		return false
+	}
+	if !slices.Equal(s.Nameservers, expandStringList(d.Get("nameservers").([]interface{}))) {
+		return false
+	}
+	if strings.EqualFold(s.Kind, "Slave") {
+		masters := make([]string, len(s.Masters))
+		for i, master := range s.Masters {
+			masters[i] = NormalizeMasterAddress(master)
+		}
+		slices.Sort(masters)
+		return slices.Equal(masters, expandStringSet(d.Get("masters").(*schema.Set)))
+	}
+	return true
+}
+
+func resourcePDNSReverseZonesUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderClients)
+
+	ctx = tflog.SetField(ctx, "cidr", d.Id())
+	tflog.Debug(ctx, "Updating reverse zones")
+
+	oldNames, _ := d.GetChange("zone_names")
+	existing := expandStringList(oldNames.([]interface{}))
+
+	_, names, err := reverseZonesForCIDR(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+	for _, name := range names {
+		if !slices.Contains(existing, name) {
+			if _, err := createReverseZone(ctx, client.PDNS, name, d); err != nil {
+				return diag.FromErr(fmt.Errorf("error creating zone %s: %w", name, err))
+			}
+			tflog.Info(ctx, "Created missing reverse zone", map[string]any{"zone": name})
+			continue
+		}
+		warnings, err := updateReverseZone(ctx, client.PDNS, name, d)
+		if err != nil {
+			return append(diags, diag.FromErr(fmt.Errorf("error updating zone %s: %w", name, err))...)
+		}
+		diags = append(diags, warnings...)
+	}
+
+	return append(diags, resourcePDNSReverseZonesRead(ctx, d, meta)...)
+}
+
+func resourcePDNSReverseZonesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderClients)
+
+	ctx = tflog.SetField(ctx, "cidr", d.Id())
+	tflog.Debug(ctx, "Deleting reverse zones")
+
+	// Every zone is checked before the first one is deleted, so a refused
+	// destroy leaves all of them in place.
+	names := expandStringList(d.Get("zone_names").([]interface{}))
+	for _, name := range names {
+		if err := checkZoneDeletion(ctx, client.PDNS, name, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	for _, name := range names {
+		if err := client.PDNS.DeleteZone(ctx, name); err != nil {
+			return diag.FromErr(fmt.Errorf("error deleting zone: %w", err))
+		}
+		tflog.Info(ctx, "Deleted reverse zone", map[string]any{"zone": name})
+	}
+
+	return nil
+}
+
+// resourcePDNSReverseZonesCustomizeDiff plans the full list of zones. When a
+// zone has gone missing, Read leaves it out of zone_names, and the resulting
+// diff makes Update create it again.
+func resourcePDNSReverseZonesCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if err := customizeZoneCatalogDiff(ctx, d, meta); err != nil {
+		return err
+	}
+	if err := customizeZoneKindDiff(d, fmt.Sprintf("the zones of %s", d.Id())); err != nil {
+		return err
+	}
+
+	cidr := d.Get("cidr").(string)
+	if cidr == "" || !d.NewValueKnown("cidr") {
+		return nil
+	}
+
+	blocks, names, err := reverseZonesForCIDR(cidr)
+	if err != nil {
+		return err
+	}
+
+	if !slices.Equal(expandStringList(d.Get("cidrs").([]interface{})), blocks) {
+		if err := d.SetNew("cidrs", blocks); err != nil {
+			return err
+		}
+	}
+	if !slices.Equal(expandStringList(d.Get("zone_names").([]interface{})), names) {
+		if err := d.SetNew("zone_names", names); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resourcePDNSReverseZonesImport accepts the CIDR the zones were created for.
+func resourcePDNSReverseZonesImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	tflog.Info(ctx, "Importing reverse zones", map[string]any{"cidr": d.Id()})
+
+	_, block, err := net.ParseCIDR(d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("invalid import ID %q, expected a CIDR: %w", d.Id(), err)
+	}
+	if _, err := SplitReverseZoneCIDR(block.String()); err != nil {
+		return nil, fmt.Errorf("invalid import ID %q: %w", d.Id(), err)
+	}
+
+	if err := d.Set("cidr", block.String()); err != nil {
+		return nil, fmt.Errorf("error setting cidr: %w", err)
+	}
+	d.SetId(block.String())
+	return []*schema.ResourceData{d}, nil
+}
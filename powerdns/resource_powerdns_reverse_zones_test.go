@@ -0,0 +1,173 @@
+package powerdns
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReverseZonesReadWithMissingZone(t *testing.T) {
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		if r.URL.Path == "/api/v1/servers/localhost/zones/2.0.192.in-addr.arpa." {
+			return jsonResponse(http.StatusOK, `{"name": "2.0.192.in-addr.arpa.", "kind": "Native", "rrsets": [
+				{"name": "2.0.192.in-addr.arpa.", "type": "NS", "ttl": 3600, "records": [{"content": "ns1.example.com."}]}
+			]}`), nil
+		}
+		return jsonResponse(http.StatusNotFound, `{"error": "Not Found"}`), nil
+	})
+
+	d := schema.TestResourceDataRaw(t, resourcePDNSReverseZones().Schema, map[string]interface{}{
+		"cidr": "192.0.2.0/23",
+		"kind": "Native",
+	})
+	d.SetId("192.0.2.0/23")
+
+	// The zone that is gone is left out, so the next plan creates it again.
+	diags := resourcePDNSReverseZonesRead(context.Background(), d, &ProviderClients{PDNS: client})
+	if assert.False(t, diags.HasError(), diags) {
+		assert.Equal(t, "192.0.2.0/23", d.Id())
+		assert.Equal(t, []interface{}{"2.0.192.in-addr.arpa."}, d.Get("zone_names"))
+		assert.Equal(t, []interface{}{"ns1.example.com."}, d.Get("nameservers"))
+	}
+}
+
+func TestAccPowerDNSReverseZones_IPv6NonNibble(t *testing.T) {
+	resourceName := "powerdns_reverse_zones.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSReverseZonesDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPowerDNSReverseZonesConfig("2001:db8:4c::/46", "ops"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPDNSReverseZonesExist(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "zone_names.#", "4"),
+					resource.TestCheckResourceAttr(resourceName, "zone_names.0", "c.4.0.0.8.b.d.0.1.0.0.2.ip6.arpa."),
+					resource.TestCheckResourceAttr(resourceName, "zone_names.3", "f.4.0.0.8.b.d.0.1.0.0.2.ip6.arpa."),
+					resource.TestCheckResourceAttr(resourceName, "cidrs.1", "2001:db8:4d::/48"),
+					resource.TestCheckResourceAttr(resourceName, "account", "ops"),
+					resource.TestCheckResourceAttr(resourceName, "nameservers.0", "ns1.sysa.abc."),
+				),
+			},
+			{
+				// The settings of every zone are updated in place.
+				Config: testAccPowerDNSReverseZonesConfig("2001:db8:4c::/46", "netops"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPDNSReverseZonesExist(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "account", "netops"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportStateId:     "2001:db8:4c::/46",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccPowerDNSReverseZones_IPv4(t *testing.T) {
+	resourceName := "powerdns_reverse_zones.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSReverseZonesDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPowerDNSReverseZonesConfig("198.18.48.0/23", "ops"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPDNSReverseZonesExist(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "zone_names.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "zone_names.0", "48.18.198.in-addr.arpa."),
+					resource.TestCheckResourceAttr(resourceName, "zone_names.1", "49.18.198.in-addr.arpa."),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPowerDNSReverseZones_InvalidCIDR(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccPowerDNSReverseZonesConfig("2001:db8::/126", "ops"),
+				ExpectError: regexp.MustCompile("IPv6 prefix length must be between 1 and 124"),
+			},
+		},
+	})
+}
+
+func testAccCheckPDNSReverseZonesExist(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*ProviderClients).PDNS
+		count, _ := strconv.Atoi(rs.Primary.Attributes["zone_names.#"])
+		if count == 0 {
+			return fmt.Errorf("No zones in %s", n)
+		}
+		for i := 0; i < count; i++ {
+			name := rs.Primary.Attributes[fmt.Sprintf("zone_names.%d", i)]
+			exists, err := client.ZoneExists(context.Background(), name)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return fmt.Errorf("Zone does not exist: %s", name)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckPDNSReverseZonesDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ProviderClients).PDNS
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "powerdns_reverse_zones" {
+			continue
+		}
+
+		_, names, err := reverseZonesForCIDR(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			exists, err := client.ZoneExists(context.Background(), name)
+			if err != nil {
+				return fmt.Errorf("Error checking if zone still exists: %s", name)
+			}
+			if exists {
+				return fmt.Errorf("Zone still exists: %s", name)
+			}
+		}
+	}
+	return nil
+}
+
+func testAccPowerDNSReverseZonesConfig(cidr string, account string) string {
+	return fmt.Sprintf(`
+resource "powerdns_reverse_zones" "test" {
+  cidr        = %q
+  kind        = "Master"
+  account     = %q
+  nameservers = ["ns1.sysa.abc.", "ns2.sysa.abc."]
+}`, cidr, account)
+}
@@ -0,0 +1,77 @@
+package powerdns
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var srvRecordType = structuredRecordType{
+	types: []string{"SRV"},
+	record: map[string]*schema.Schema{
+		"priority": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.IntBetween(0, 65535),
+			Description:  "The priority of the target host. Lower values are preferred.",
+		},
+		"weight": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.IntBetween(0, 65535),
+			Description:  "The relative weight for records with the same priority.",
+		},
+		"port": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.IntBetween(0, 65535),
+			Description:  "The port on the target host of the service.",
+		},
+		"target": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: ValidateFQDN,
+			Description:  "The target host name. Must be a fully qualified domain name ending with a trailing dot, or \".\" when the service is not available.",
+		},
+	},
+	build: buildSRVContent,
+	parse: parseSRVContent,
+}
+
+func resourcePDNSSRVRecord() *schema.Resource {
+	return resourcePDNSStructuredRecord(srvRecordType)
+}
+
+func buildSRVContent(record map[string]interface{}) (string, error) {
+	return fmt.Sprintf("%d %d %d %s",
+		record["priority"].(int),
+		record["weight"].(int),
+		record["port"].(int),
+		record["target"].(string),
+	), nil
+}
+
+func parseSRVContent(content string) (map[string]interface{}, error) {
+	fields, err := splitRecordContentFields(content)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) != 4 {
+		return nil, fmt.Errorf("expected 4 fields, got %d", len(fields))
+	}
+
+	names := []string{"priority", "weight", "port"}
+	parsed := make(map[string]interface{}, 4)
+	for i, name := range names {
+		value, err := strconv.ParseUint(fields[i], 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", name, fields[i], err)
+		}
+		parsed[name] = int(value)
+	}
+	parsed["target"] = fields[3]
+
+	return parsed, nil
+}
@@ -0,0 +1,72 @@
+package powerdns
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildSRVContent(t *testing.T) {
+	content, err := buildSRVContent(map[string]interface{}{"priority": 10, "weight": 60, "port": 5060, "target": "sip.example.com."})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "10 60 5060 sip.example.com.", content)
+	}
+}
+
+func TestParseSRVContentInvalid(t *testing.T) {
+	for _, content := range []string{"10 60 sip.example.com.", "10 60 http sip.example.com."} {
+		_, err := parseSRVContent(content)
+		assert.Error(t, err, content)
+	}
+}
+
+func TestAccPDNSSRVRecord_Basic(t *testing.T) {
+	resourceName := "powerdns_srv_record.test"
+	resourceID := `{"zone":"rec-srv-typed.sysa.xyz.","id":"_sip._tcp.rec-srv-typed.sysa.xyz.:::SRV"}`
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSRecordDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testPDNSSRVRecordConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPDNSRecordExists(resourceName),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "record.*", map[string]string{
+						"priority": "10",
+						"weight":   "60",
+						"port":     "5060",
+						"target":   "sip.sysa.xyz.",
+					}),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportStateId:     resourceID,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testPDNSSRVRecordConfig = `
+resource "powerdns_zone" "test-zone" {
+	name = "rec-srv-typed.sysa.xyz."
+	kind = "Native"
+}
+
+resource "powerdns_srv_record" "test" {
+	zone = powerdns_zone.test-zone.name
+	name = "_sip._tcp.rec-srv-typed.sysa.xyz."
+	ttl = 60
+
+	record {
+		priority = 10
+		weight = 60
+		port = 5060
+		target = "sip.sysa.xyz."
+	}
+}`
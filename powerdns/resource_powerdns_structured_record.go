@@ -0,0 +1,369 @@
+package powerdns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// structuredRecordType describes a typed record resource such as
+// powerdns_mx_record. Each RRset entry is a "record" block whose attributes
+// are serialized into PowerDNS content on write and parsed back on read, so
+// the state holds typed values instead of free-form strings.
+type structuredRecordType struct {
+	// types lists the RRset types the resource can manage. When there is more
+	// than one, the resource gets a "type" argument defaulting to the first.
+	types []string
+	// record is the schema of a single record block.
+	record map[string]*schema.Schema
+	// build renders a record block as PowerDNS content.
+	build func(record map[string]interface{}) (string, error)
+	// parse turns PowerDNS content back into a record block.
+	parse func(content string) (map[string]interface{}, error)
+	// validate, if set, checks the record blocks of an RRset together.
+	validate func(records []map[string]interface{}) error
+}
+
+func resourcePDNSStructuredRecord(t structuredRecordType) *schema.Resource {
+	resourceSchema := map[string]*schema.Schema{
+		"zone": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: ValidateZoneName,
+			Description:  "The name of the zone to contain this record.",
+		},
+		"name": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: ValidateFQDN,
+			Description:  "The fully qualified name of the record, ending with a trailing dot.",
+		},
+		"ttl": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "The TTL of the RRset in seconds.",
+		},
+		"record": {
+			Type:        schema.TypeSet,
+			Required:    true,
+			MinItems:    1,
+			Elem:        &schema.Resource{Schema: t.record},
+			Description: fmt.Sprintf("The %s records in the RRset.", strings.Join(t.types, "/")),
+		},
+	}
+
+	if len(t.types) > 1 {
+		resourceSchema["type"] = &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			Default:      t.types[0],
+			ValidateFunc: validation.StringInSlice(t.types, false),
+			Description:  fmt.Sprintf("The RRset type, one of %s.", strings.Join(t.types, ", ")),
+		}
+	}
+
+	upsert := func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		return resourcePDNSStructuredRecordUpsert(ctx, d, meta, t)
+	}
+
+	return &schema.Resource{
+		CreateContext: upsert,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return resourcePDNSStructuredRecordRead(ctx, d, meta, t)
+		},
+		UpdateContext: upsert,
+		DeleteContext: resourcePDNSStructuredRecordDelete,
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			return resourcePDNSStructuredRecordCustomizeDiff(d, t)
+		},
+
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				return resourcePDNSStructuredRecordImport(ctx, d, meta, t)
+			},
+		},
+
+		Schema: resourceSchema,
+	}
+}
+
+// rrType returns the RRset type managed by a structured record resource.
+func (t structuredRecordType) rrType(d *schema.ResourceData) string {
+	if len(t.types) > 1 {
+		return d.Get("type").(string)
+	}
+	return t.types[0]
+}
+
+func resourcePDNSStructuredRecordUpsert(ctx context.Context, d *schema.ResourceData, meta interface{}, t structuredRecordType) diag.Diagnostics {
+	client := meta.(*ProviderClients)
+
+	zone := d.Get("zone").(string)
+	name := d.Get("name").(string)
+	typ := t.rrType(d)
+	ttl := d.Get("ttl").(int)
+
+	rawRecords := d.Get("record").(*schema.Set).List()
+	records := make([]Record, 0, len(rawRecords))
+	for _, raw := range rawRecords {
+		content, err := t.build(raw.(map[string]interface{}))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("invalid %s record: %w", typ, err))
+		}
+
+		records = append(records, Record{
+			Name:    name,
+			Type:    typ,
+			TTL:     ttl,
+			Content: content,
+		})
+	}
+
+	if err := t.validateRecords(rawRecords); err != nil {
+		return diag.FromErr(fmt.Errorf("invalid %s record: %w", typ, err))
+	}
+
+	rrSet := ResourceRecordSet{
+		Name:    name,
+		Type:    typ,
+		TTL:     ttl,
+		Records: records,
+	}
+
+	action := "create"
+	if d.Id() != "" {
+		action = "update"
+	}
+
+	ctx = tflog.SetField(ctx, "zone", zone)
+	ctx = tflog.SetField(ctx, "name", name)
+	ctx = tflog.SetField(ctx, "type", typ)
+	if action == "create" {
+		tflog.Debug(ctx, "Creating PowerDNS record set")
+	} else {
+		tflog.Debug(ctx, "Updating PowerDNS record set", map[string]any{"id": d.Id()})
+	}
+
+	recID, err := client.PDNS.ReplaceRecordSet(ctx, zone, rrSet)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to %s PowerDNS %s Record: %w", action, typ, err))
+	}
+
+	d.SetId(recID)
+	if action == "create" {
+		tflog.Info(ctx, "Created PowerDNS Record", map[string]any{"id": recID})
+	} else {
+		tflog.Info(ctx, "Updated PowerDNS Record", map[string]any{"id": recID})
+	}
+
+	return resourcePDNSStructuredRecordRead(ctx, d, meta, t)
+}
+
+// validateRecords runs the RRset-wide check of t, if any, on raw record
+// blocks.
+func (t structuredRecordType) validateRecords(rawRecords []interface{}) error {
+	if t.validate == nil {
+		return nil
+	}
+	records := make([]map[string]interface{}, len(rawRecords))
+	for i, raw := range rawRecords {
+		records[i] = raw.(map[string]interface{})
+	}
+	return t.validate(records)
+}
+
+// resourcePDNSStructuredRecordCustomizeDiff builds the planned records, so
+// invalid content fails the plan instead of the apply. It waits until every
+// record block is known.
+func resourcePDNSStructuredRecordCustomizeDiff(d *schema.ResourceDiff, t structuredRecordType) error {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() || !rawConfig.GetAttr("record").IsWhollyKnown() {
+		return nil
+	}
+
+	typ := t.types[0]
+	if len(t.types) > 1 {
+		typ = d.Get("type").(string)
+	}
+
+	rawRecords := d.Get("record").(*schema.Set).List()
+	for _, raw := range rawRecords {
+		if _, err := t.build(raw.(map[string]interface{})); err != nil {
+			return fmt.Errorf("invalid %s record: %w", typ, err)
+		}
+	}
+	if err := t.validateRecords(rawRecords); err != nil {
+		return fmt.Errorf("invalid %s record: %w", typ, err)
+	}
+	return nil
+}
+
+func resourcePDNSStructuredRecordRead(ctx context.Context, d *schema.ResourceData, meta interface{}, t structuredRecordType) diag.Diagnostics {
+	client := meta.(*ProviderClients)
+
+	zone := d.Get("zone").(string)
+	tflog.SetField(ctx, "zone", zone)
+	tflog.SetField(ctx, "record_id", d.Id())
+	tflog.Debug(ctx, "Reading PowerDNS Record")
+
+	rrSet, err := client.PDNS.GetRecordSetByID(ctx, zone, d.Id())
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't fetch PowerDNS RRset details: %w", err))
+	}
+	if rrSet == nil || len(rrSet.Records) == 0 {
+		tflog.Warn(ctx, "PowerDNS Record not found; removing from state")
+		d.SetId("")
+		return nil
+	}
+
+	if err := setStructuredRecordState(d, rrSet, t); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func setStructuredRecordState(d *schema.ResourceData, rrSet *ResourceRecordSet, t structuredRecordType) error {
+	records, err := flattenStructuredRecords(rrSet, t)
+	if err != nil {
+		return err
+	}
+
+	if err := d.Set("name", rrSet.Name); err != nil {
+		return fmt.Errorf("error setting PowerDNS Name: %w", err)
+	}
+	if err := d.Set("ttl", rrSet.TTL); err != nil {
+		return fmt.Errorf("error setting PowerDNS TTL: %w", err)
+	}
+	if err := d.Set("record", records); err != nil {
+		return fmt.Errorf("error setting PowerDNS Records: %w", err)
+	}
+	if len(t.types) > 1 {
+		if err := d.Set("type", strings.ToUpper(rrSet.Type)); err != nil {
+			return fmt.Errorf("error setting PowerDNS Type: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func flattenStructuredRecords(rrSet *ResourceRecordSet, t structuredRecordType) ([]map[string]interface{}, error) {
+	records := make([]map[string]interface{}, 0, len(rrSet.Records))
+	for _, record := range rrSet.Records {
+		parsed, err := t.parse(record.Content)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s content %q: %w", rrSet.Type, record.Content, err)
+		}
+		records = append(records, parsed)
+	}
+
+	return records, nil
+}
+
+func resourcePDNSStructuredRecordDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderClients)
+
+	zone := d.Get("zone").(string)
+	tflog.SetField(ctx, "zone", zone)
+	tflog.SetField(ctx, "record_id", d.Id())
+	tflog.Debug(ctx, "Deleting PowerDNS Record")
+
+	if err := client.PDNS.DeleteRecordSetByID(ctx, zone, d.Id()); err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting PowerDNS Record: %w", err))
+	}
+
+	tflog.Info(ctx, "Deleted PowerDNS Record")
+	return nil
+}
+
+func resourcePDNSStructuredRecordImport(ctx context.Context, d *schema.ResourceData, meta interface{}, t structuredRecordType) ([]*schema.ResourceData, error) {
+	client := meta.(*ProviderClients)
+
+	tflog.Info(ctx, "Importing PowerDNS Record", map[string]any{"id": d.Id()})
+
+	zoneName, recordID, err := parseRecordImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	_, typ, err := parseID(recordID)
+	if err != nil {
+		return nil, err
+	}
+	if !containsFold(t.types, typ) {
+		return nil, fmt.Errorf("record type %s cannot be imported into this resource, expected one of %s", typ, strings.Join(t.types, ", "))
+	}
+
+	rrSet, err := client.PDNS.GetRecordSetByID(ctx, zoneName, recordID)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't fetch PowerDNS RRset details: %w", err)
+	}
+	if rrSet == nil || len(rrSet.Records) == 0 {
+		return nil, fmt.Errorf("rrset has no records to import")
+	}
+
+	if err := d.Set("zone", zoneName); err != nil {
+		return nil, fmt.Errorf("error setting PowerDNS Zone: %w", err)
+	}
+	if err := setStructuredRecordState(d, rrSet, t); err != nil {
+		return nil, err
+	}
+
+	d.SetId(recordID)
+	return []*schema.ResourceData{d}, nil
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitRecordContentFields splits record content on whitespace, keeping
+// double-quoted strings (with their quotes) together as one field.
+func splitRecordContentFields(content string) ([]string, error) {
+	var fields []string
+	var current strings.Builder
+	inQuotes := false
+	escaped := false
+
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '"':
+			inQuotes = !inQuotes
+		case (c == ' ' || c == '\t') && !inQuotes:
+			if current.Len() > 0 {
+				fields = append(fields, current.String())
+				current.Reset()
+			}
+			continue
+		}
+		current.WriteByte(c)
+	}
+
+	if inQuotes {
+		return nil, fmt.Errorf("unterminated quoted string in %q", content)
+	}
+	if current.Len() > 0 {
+		fields = append(fields, current.String())
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,97 @@
+package powerdns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitRecordContentFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "Plain fields",
+			input:    "10 60 5060 sip.example.com.",
+			expected: []string{"10", "60", "5060", "sip.example.com."},
+		},
+		{
+			name:     "Quoted field with spaces",
+			input:    `0 iodef "mailto:security team@example.com"`,
+			expected: []string{"0", "iodef", `"mailto:security team@example.com"`},
+		},
+		{
+			name:     "Escaped quote inside quoted field",
+			input:    `0 issue "ca.example.net; account=\"x y\""`,
+			expected: []string{"0", "issue", `"ca.example.net; account=\"x y\""`},
+		},
+		{
+			name:     "Repeated whitespace",
+			input:    "1  .   alpn=h2",
+			expected: []string{"1", ".", "alpn=h2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, err := splitRecordContentFields(tt.input)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.expected, fields)
+			}
+		})
+	}
+}
+
+func TestSplitRecordContentFieldsUnterminatedQuote(t *testing.T) {
+	_, err := splitRecordContentFields(`0 issue "letsencrypt.org`)
+	assert.Error(t, err)
+}
+
+func TestStructuredRecordTypesRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		t      structuredRecordType
+		record map[string]interface{}
+	}{
+		{
+			name:   "MX",
+			t:      mxRecordType,
+			record: map[string]interface{}{"preference": 10, "exchange": "mail.example.com."},
+		},
+		{
+			name:   "SRV",
+			t:      srvRecordType,
+			record: map[string]interface{}{"priority": 10, "weight": 60, "port": 5060, "target": "sip.example.com."},
+		},
+		{
+			name:   "CAA",
+			t:      caaRecordType,
+			record: map[string]interface{}{"flags": 128, "tag": "issue", "value": `letsencrypt.org; validationmethods="dns-01"`},
+		},
+		{
+			name: "HTTPS",
+			t:    httpsRecordType,
+			record: map[string]interface{}{
+				"priority": 1,
+				"target":   ".",
+				"params":   map[string]interface{}{"alpn": "h2,h3", "no-default-alpn": "", "port": "8443"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := tt.t.build(tt.record)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			parsed, err := tt.t.parse(content)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.record, parsed)
+			}
+		})
+	}
+}
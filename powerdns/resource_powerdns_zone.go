@@ -3,6 +3,8 @@ package powerdns
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -17,6 +19,7 @@ func resourcePDNSZone() *schema.Resource {
 		ReadContext:   resourcePDNSZoneRead,
 		UpdateContext: resourcePDNSZoneUpdate,
 		DeleteContext: resourcePDNSZoneDelete,
+		CustomizeDiff: resourcePDNSZoneCustomizeDiff,
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -53,36 +56,254 @@ func resourcePDNSZone() *schema.Resource {
 				ValidateFunc: ValidateZoneName,
 			},
 
-			"masters": {
-				Type: schema.TypeSet,
-				// TypeSet identities are computed before an element StateFunc runs.
-				// Hashing the canonical form makes expanded and compressed IPv6
-				// spellings represent the same set element.
-				Set: func(value interface{}) int {
-					return schema.HashString(NormalizeMasterAddress(value.(string)))
+			"masters": zoneMastersSchema(),
+
+			"soa_edit_api": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: false,
+			},
+
+			// zone_file is create-only. Only a hash of the zone file is kept
+			// in state and it is never compared with the server. PowerDNS
+			// reads the file once, when the zone is created, so a changed
+			// file replaces the zone. Removing the file after the migration
+			// does not.
+			"zone_file": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateZoneFile,
+				StateFunc: func(value interface{}) string {
+					return zoneFileHash(value.(string))
+				},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return new == ""
 				},
+				Description: "A BIND zone file to create the zone from, given as its contents or as a path. Changes to the zone on the server are not detected.",
+			},
+
+			// nameservers and initial_rrsets are bootstrap data. They are
+			// sent with the request that creates the zone and are not read
+			// back, so later changes, on either side, are ignored.
+			"nameservers": {
+				Type:     schema.TypeList,
+				Optional: true,
 				Elem: &schema.Schema{
 					Type:         schema.TypeString,
-					ValidateFunc: ValidateMasterAddress,
-					// PowerDNS returns IPv6 addresses in compressed form. Normalizing
-					// the configured value prevents a perpetual diff when users write
-					// an equivalent expanded address.
-					StateFunc: func(value interface{}) string {
-						return NormalizeMasterAddress(value.(string))
+					ValidateFunc: ValidateFQDN,
+				},
+				ConflictsWith:    []string{"zone_file"},
+				DiffSuppressFunc: suppressDiffAfterCreate,
+				Description:      "The nameservers to create the apex NS records with. Only used when the zone is created.",
+			},
+
+			"initial_rrsets": {
+				Type:             schema.TypeList,
+				Optional:         true,
+				ConflictsWith:    []string{"zone_file"},
+				DiffSuppressFunc: suppressDiffAfterCreate,
+				Description:      "RRsets to create together with the zone. Only used when the zone is created.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: ValidateRecordName,
+							Description:  "The name of the RRset: a fully qualified name with a trailing dot, \"@\" for the zone apex, or a name relative to the zone.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The record type of the RRset.",
+						},
+						"ttl": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     3600,
+							Description: "The TTL of the RRset.",
+						},
+						"records": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The contents of the records in the RRset.",
+						},
 					},
 				},
-				Optional: true,
 			},
 
-			"soa_edit_api": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: false,
+			"axfr_retrieve": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to transfer the zone from its primaries right away when it is created as, or changed to, a Slave zone.",
 			},
+
+			"force_destroy":       zoneForceDestroySchema(),
+			"deletion_protection": zoneDeletionProtectionSchema(),
 		},
 	}
 }
 
+// suppressDiffAfterCreate ignores changes to attributes that are only used
+// when the zone is created. A diff that replaces the zone is computed without
+// state, so the new zone is created with the configured values.
+func suppressDiffAfterCreate(k, old, new string, d *schema.ResourceData) bool {
+	return d.Id() != ""
+}
+
+// expandInitialRRSets turns the initial_rrsets blocks into the RRsets sent
+// with the request that creates zone. Relative names are resolved against the
+// zone, and names outside of it are rejected.
+func expandInitialRRSets(zone string, blocks []interface{}) ([]ResourceRecordSet, error) {
+	rrSets := make([]ResourceRecordSet, 0, len(blocks))
+	seen := map[string]bool{}
+	for i, block := range blocks {
+		m := block.(map[string]interface{})
+		name := resolveRecordName(m["name"].(string), zone)
+		if !recordNameInZone(name, zone) {
+			return nil, fmt.Errorf("initial_rrsets.%d: %s is outside zone %s", i, name, zone)
+		}
+
+		rrSet := ResourceRecordSet{
+			Name: name,
+			Type: strings.ToUpper(m["type"].(string)),
+			TTL:  m["ttl"].(int),
+		}
+		if seen[rrSet.ID()] {
+			return nil, fmt.Errorf("initial_rrsets.%d: duplicate RRset %s %s", i, rrSet.Name, rrSet.Type)
+		}
+		seen[rrSet.ID()] = true
+
+		for _, content := range m["records"].([]interface{}) {
+			rrSet.Records = append(rrSet.Records, Record{
+				Name:    rrSet.Name,
+				Type:    rrSet.Type,
+				TTL:     rrSet.TTL,
+				Content: content.(string),
+			})
+		}
+		rrSets = append(rrSets, rrSet)
+	}
+	return rrSets, nil
+}
+
+// zoneMastersSchema is the schema of the masters attribute, shared by
+// powerdns_zone and powerdns_reverse_zone.
+func zoneMastersSchema() *schema.Schema {
+	return &schema.Schema{
+		Type: schema.TypeSet,
+		// TypeSet identities are computed before an element StateFunc runs.
+		// Hashing the canonical form makes expanded and compressed IPv6
+		// spellings represent the same set element.
+		Set: func(value interface{}) int {
+			return schema.HashString(NormalizeMasterAddress(value.(string)))
+		},
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: ValidateMasterAddress,
+			// PowerDNS returns IPv6 addresses in compressed form. Normalizing
+			// the configured value prevents a perpetual diff when users write
+			// an equivalent expanded address.
+			StateFunc: func(value interface{}) string {
+				return NormalizeMasterAddress(value.(string))
+			},
+		},
+		Optional: true,
+	}
+}
+
+// zoneForceDestroySchema is the schema of the force_destroy attribute of the
+// zone resources.
+func zoneForceDestroySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Whether to delete the zone even if it still contains records other than the SOA and the apex NS records.",
+	}
+}
+
+// zoneDeletionProtectionSchema is the schema of the deletion_protection
+// attribute of the zone resources.
+func zoneDeletionProtectionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Whether destroying the zone is refused. It has to be set to false and applied before the zone can be destroyed.",
+	}
+}
+
+// setZoneDeletionDefaults writes force_destroy and deletion_protection, which
+// only live in state, back to state, so that an import stores their defaults.
+func setZoneDeletionDefaults(d *schema.ResourceData) error {
+	for _, key := range []string{"force_destroy", "deletion_protection"} {
+		if err := d.Set(key, d.Get(key)); err != nil {
+			return fmt.Errorf("error setting %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// checkZoneDeletion returns an error if zoneName must not be deleted: when
+// deletion_protection is set, or when the zone still holds records other
+// than its SOA and apex NS records and force_destroy is not set. Secondary
+// zones are not checked for records, as their primaries hold them.
+func checkZoneDeletion(ctx context.Context, client *PowerDNSClient, zoneName string, d *schema.ResourceData) error {
+	if err := checkZoneDeletionProtection(zoneName, d); err != nil {
+		return err
+	}
+	if d.Get("force_destroy").(bool) || isSecondaryZoneKind(d.Get("kind").(string)) {
+		return nil
+	}
+
+	zone, err := client.GetZoneWithRRsets(ctx, zoneName)
+	if err != nil {
+		return fmt.Errorf("couldn't fetch records of zone %s: %w", zoneName, err)
+	}
+
+	var remaining []string
+	for _, rrSet := range zone.ResourceRecordSets {
+		if len(rrSet.Records) == 0 {
+			continue
+		}
+		apex := strings.EqualFold(rrSet.Name, zone.Name)
+		if apex && (strings.EqualFold(rrSet.Type, "SOA") || strings.EqualFold(rrSet.Type, "NS")) {
+			continue
+		}
+		remaining = append(remaining, rrSet.Name+" "+rrSet.Type)
+	}
+	if len(remaining) == 0 {
+		return nil
+	}
+
+	sort.Strings(remaining)
+	examples := remaining
+	if len(examples) > 5 {
+		examples = append(examples[:5:5], "...")
+	}
+	tflog.Warn(ctx, "Refusing to delete zone with records", map[string]any{"zone": zoneName, "rrsets": len(remaining)})
+	return fmt.Errorf("zone %s still contains %d RRsets besides the SOA and apex NS records (%s); set force_destroy = true to delete it with its records",
+		zoneName, len(remaining), strings.Join(examples, ", "))
+}
+
+// checkZoneDeletionProtection returns an error if deletion_protection is set.
+func checkZoneDeletionProtection(zoneName string, d *schema.ResourceData) error {
+	if d.Get("deletion_protection").(bool) {
+		return fmt.Errorf("zone %s has deletion_protection enabled; set deletion_protection = false and apply before destroying it", zoneName)
+	}
+	return nil
+}
+
+// isSecondaryZoneKind reports whether zones of kind get their records by
+// zone transfer from a primary.
+func isSecondaryZoneKind(kind string) bool {
+	return strings.EqualFold(kind, "Slave") || strings.EqualFold(kind, "Consumer")
+}
+
 func resourcePDNSZoneCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*ProviderClients)
 
@@ -98,10 +319,23 @@ func resourcePDNSZoneCreate(ctx context.Context, d *schema.ResourceData, meta in
 		Kind:        d.Get("kind").(string),
 		Catalog:     d.Get("catalog").(string),
 		Account:     d.Get("account").(string),
-		Nameservers: []string{},
+		Nameservers: expandStringList(d.Get("nameservers").([]interface{})),
 		SoaEditAPI:  d.Get("soa_edit_api").(string),
 	}
 
+	rrSets, err := expandInitialRRSets(zoneInfo.Name, d.Get("initial_rrsets").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	for _, rrSet := range rrSets {
+		// PowerDNS refuses a create request that sets the apex NS records
+		// twice.
+		if len(zoneInfo.Nameservers) > 0 && rrSet.Type == "NS" && strings.EqualFold(rrSet.Name, zoneBaseName(zoneInfo.Name)) {
+			return diag.FromErr(fmt.Errorf("the apex NS records can be set by nameservers or by initial_rrsets, not both"))
+		}
+	}
+	zoneInfo.ResourceRecordSets = rrSets
+
 	if len(masters) != 0 {
 		if strings.EqualFold(zoneInfo.Kind, "Slave") {
 			zoneInfo.Masters = masters
@@ -110,6 +344,30 @@ func resourcePDNSZoneCreate(ctx context.Context, d *schema.ResourceData, meta in
 		}
 	}
 
+	if zoneFile := d.Get("zone_file").(string); zoneFile != "" {
+		content, err := readZoneFile(zoneFile)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		records, err := parseZoneFile(content, zoneInfo.Name)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("invalid zone_file: %w", err))
+		}
+		for _, record := range records {
+			if !recordNameInZone(record.name, zoneInfo.Name) {
+				return diag.FromErr(fmt.Errorf("invalid zone_file: line %d: %s is outside zone %s", record.line, record.name, zoneInfo.Name))
+			}
+		}
+		zoneInfo.Zone = content
+		tflog.Debug(ctx, "Creating zone from zone file", map[string]any{"records": len(records)})
+	}
+
+	if zoneInfo.Catalog != "" {
+		if err := checkZoneCatalog(ctx, client.PDNS, zoneInfo.Catalog); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	tflog.SetField(ctx, "zone_name", zoneInfo.Name)
 	tflog.SetField(ctx, "zone_kind", zoneInfo.Kind)
 	tflog.Debug(ctx, "Creating PowerDNS Zone")
@@ -121,6 +379,12 @@ func resourcePDNSZoneCreate(ctx context.Context, d *schema.ResourceData, meta in
 
 	d.SetId(createdZoneInfo.ID)
 	tflog.Info(ctx, "Created PowerDNS Zone", map[string]any{"id": createdZoneInfo.ID})
+
+	if strings.EqualFold(zoneInfo.Kind, "Slave") && d.Get("axfr_retrieve").(bool) {
+		if err := client.PDNS.RetrieveZone(ctx, d.Id()); err != nil {
+			return diag.FromErr(fmt.Errorf("error retrieving PowerDNS Zone from its primaries: %w", err))
+		}
+	}
 	return resourcePDNSZoneRead(ctx, d, meta)
 }
 
@@ -163,6 +427,13 @@ func resourcePDNSZoneRead(ctx context.Context, d *schema.ResourceData, meta inte
 		}
 	}
 
+	if err := d.Set("axfr_retrieve", d.Get("axfr_retrieve")); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting axfr_retrieve: %w", err))
+	}
+	if err := setZoneDeletionDefaults(d); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return nil
 }
 
@@ -172,27 +443,135 @@ func resourcePDNSZoneUpdate(ctx context.Context, d *schema.ResourceData, meta in
 
 	client := meta.(*ProviderClients)
 
+	var diags diag.Diagnostics
 	if d.HasChange("kind") || d.HasChange("account") || d.HasChange("catalog") || d.HasChange("soa_edit_api") || d.HasChange("masters") {
 		var masters []string
 		for _, m := range d.Get("masters").(*schema.Set).List() {
 			masters = append(masters, m.(string))
 		}
 
+		soaEditAPI := d.Get("soa_edit_api").(string)
 		zoneInfo := ZoneInfoUpd{
 			Name:       d.Get("name").(string),
 			Kind:       d.Get("kind").(string),
 			Catalog:    d.Get("catalog").(string),
 			Account:    d.Get("account").(string),
-			SoaEditAPI: d.Get("soa_edit_api").(string),
+			SoaEditAPI: &soaEditAPI,
 			Masters:    masters,
 		}
 
+		// PowerDNS keeps the primaries of a zone that stops being a
+		// secondary unless they are removed explicitly.
+		oldKind, _ := d.GetChange("kind")
+		leavingSlave := d.HasChange("kind") && strings.EqualFold(oldKind.(string), "Slave")
+		if leavingSlave {
+			zoneInfo.Masters = []string{}
+		}
+
+		if d.HasChange("catalog") && zoneInfo.Catalog != "" {
+			if err := checkZoneCatalog(ctx, client.PDNS, zoneInfo.Catalog); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
 		if err := client.PDNS.UpdateZone(ctx, d.Id(), zoneInfo); err != nil {
 			return diag.FromErr(fmt.Errorf("error updating PowerDNS Zone: %w", err))
 		}
+
+		if d.HasChange("kind") {
+			tflog.Info(ctx, "Changed PowerDNS Zone kind", map[string]any{"from": oldKind, "to": zoneInfo.Kind, "cleared_masters": leavingSlave})
+
+			if strings.EqualFold(zoneInfo.Kind, "Slave") && d.Get("axfr_retrieve").(bool) {
+				if err := client.PDNS.RetrieveZone(ctx, d.Id()); err != nil {
+					return diag.FromErr(fmt.Errorf("error retrieving PowerDNS Zone from its primaries: %w", err))
+				}
+			}
+
+			metadata, err := client.PDNS.ListZoneMetadata(ctx, d.Id())
+			if err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  "Couldn't check zone metadata after changing the zone kind",
+					Detail:   err.Error(),
+				})
+			} else {
+				diags = append(diags, zoneKindWarnings(zoneInfo.Name, zoneInfo.Kind, metadata)...)
+			}
+		}
 	}
 
-	return resourcePDNSZoneRead(ctx, d, meta)
+	return append(diags, resourcePDNSZoneRead(ctx, d, meta)...)
+}
+
+// zoneKindIgnoredMetadata lists, by lower case zone kind, the metadata kinds
+// that have no effect on zones of that kind.
+var zoneKindIgnoredMetadata = map[string][]string{
+	"native": {"ALSO-NOTIFY", "AXFR-MASTER-TSIG", "IXFR", "SLAVE-RENOTIFY"},
+	"master": {"AXFR-MASTER-TSIG", "IXFR", "SLAVE-RENOTIFY"},
+	"slave":  {"NOTIFY-DNSUPDATE", "SOA-EDIT-DNSUPDATE"},
+}
+
+// zoneKindWarnings reports the metadata of a zone that no longer fits the
+// kind it was changed to. It is left in place, since it may be wanted again
+// after changing the kind back.
+func zoneKindWarnings(zoneName string, kind string, metadata []ZoneMetadata) diag.Diagnostics {
+	var diags diag.Diagnostics
+	ignored := zoneKindIgnoredMetadata[strings.ToLower(kind)]
+	for _, entry := range metadata {
+		if slices.Contains(ignored, strings.ToUpper(entry.Kind)) {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Zone metadata %s has no effect on %s zones", entry.Kind, kind),
+				Detail:   fmt.Sprintf("Zone %s was changed to %s but still has %s metadata. Remove it, for example from its powerdns_zone_metadata resource, if it is no longer needed.", zoneName, kind, entry.Kind),
+			})
+		}
+	}
+	return diags
+}
+
+// resourcePDNSZoneCustomizeDiff checks the catalog, and that masters fits the
+// kind.
+func resourcePDNSZoneCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if err := customizeZoneCatalogDiff(ctx, d, meta); err != nil {
+		return err
+	}
+	return customizeZoneKindDiff(d, fmt.Sprintf("zone %s", d.Get("name")))
+}
+
+// customizeZoneKindDiff checks that masters fits the kind: it is only
+// supported for Slave zones, and a zone that is changed to Slave needs
+// primaries to transfer it from. A zone changed to Slave also drops
+// soa_edit_api, which has no effect on it. subject names the zone in errors.
+func customizeZoneKindDiff(d *schema.ResourceDiff, subject string) error {
+	if !d.NewValueKnown("kind") || !d.NewValueKnown("masters") {
+		return nil
+	}
+	oldKind, newKind := d.GetChange("kind")
+	slave := strings.EqualFold(newKind.(string), "Slave")
+	masters := d.Get("masters").(*schema.Set)
+	switch {
+	case !slave && masters.Len() > 0:
+		return fmt.Errorf("masters attribute is supported only for Slave kind")
+	case slave && masters.Len() == 0 && d.Id() != "" && !strings.EqualFold(oldKind.(string), "Slave"):
+		return fmt.Errorf("masters must be set when changing the kind of %s to Slave", subject)
+	}
+
+	if !slave || d.Id() == "" || strings.EqualFold(oldKind.(string), "Slave") {
+		return nil
+	}
+	if rawConfig := d.GetRawConfig(); !rawConfig.IsNull() && rawConfig.IsKnown() {
+		if v := rawConfig.GetAttr("soa_edit_api"); v.IsKnown() && !v.IsNull() && v.AsString() != "" {
+			return fmt.Errorf("soa_edit_api has no effect on Slave zones; remove it when changing the kind of %s to Slave", subject)
+		}
+	}
+	// Where soa_edit_api is computed, an unset value keeps the one read
+	// from the server, as the SDK does not plan an empty string for
+	// computed attributes. It is planned as unknown instead, and cleared by
+	// the update.
+	if d.Get("soa_edit_api").(string) == "" {
+		return nil
+	}
+	return d.SetNewComputed("soa_edit_api")
 }
 
 func resourcePDNSZoneDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -201,6 +580,10 @@ func resourcePDNSZoneDelete(ctx context.Context, d *schema.ResourceData, meta in
 	tflog.SetField(ctx, "zone_id", d.Id())
 	tflog.Debug(ctx, "Deleting PowerDNS Zone")
 
+	if err := checkZoneDeletion(ctx, client.PDNS, d.Id(), d); err != nil {
+		return diag.FromErr(err)
+	}
+
 	if err := client.PDNS.DeleteZone(ctx, d.Id()); err != nil {
 		return diag.FromErr(fmt.Errorf("error deleting PowerDNS Zone: %w", err))
 	}
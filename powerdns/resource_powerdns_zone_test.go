@@ -3,11 +3,15 @@ package powerdns
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccPDNSZoneNative(t *testing.T) {
@@ -488,6 +492,359 @@ func TestAccPDNSZoneInvalidVariantMultipleSeparators(t *testing.T) {
 	})
 }
 
+func TestAccPDNSZoneZoneFile(t *testing.T) {
+	resourceName := "powerdns_zone.test-zone-file"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testPDNSZoneConfigZoneFile,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPDNSZoneExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "zone_file", zoneFileHash(testPDNSZoneFileContents)),
+					func(s *terraform.State) error {
+						client := testAccProvider.Meta().(*ProviderClients).PDNS
+						records, err := client.ListRecordsInRRSet(context.Background(), "zonefile.sysa.xyz.", "www.zonefile.sysa.xyz.", "A")
+						if err != nil {
+							return err
+						}
+						if len(records) != 1 || records[0].Content != "192.0.2.80" {
+							return fmt.Errorf("unexpected www records from zone file: %v", records)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"zone_file", "force_destroy"},
+			},
+		},
+	})
+}
+
+func TestAccPDNSZoneZoneFileInvalid(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testPDNSZoneConfigZoneFileInvalid,
+				ExpectError: regexp.MustCompile(`line 3: invalid IPv4 address`),
+			},
+		},
+	})
+}
+
+func TestAccPDNSZoneBootstrap(t *testing.T) {
+	resourceName := "powerdns_zone.test-bootstrap"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testPDNSZoneConfigBootstrap("192.0.2.53"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPDNSZoneExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "nameservers.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "initial_rrsets.#", "2"),
+					func(s *terraform.State) error {
+						client := testAccProvider.Meta().(*ProviderClients).PDNS
+						ns, err := client.ListRecordsInRRSet(context.Background(), "bootstrap.sysa.xyz.", "bootstrap.sysa.xyz.", "NS")
+						if err != nil {
+							return err
+						}
+						if len(ns) != 2 {
+							return fmt.Errorf("unexpected NS records: %v", ns)
+						}
+						records, err := client.ListRecordsInRRSet(context.Background(), "bootstrap.sysa.xyz.", "ns1.bootstrap.sysa.xyz.", "A")
+						if err != nil {
+							return err
+						}
+						if len(records) != 1 || records[0].Content != "192.0.2.53" {
+							return fmt.Errorf("unexpected ns1 records: %v", records)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// The bootstrap data is not tracked after the zone is created.
+				Config:   testPDNSZoneConfigBootstrap("192.0.2.54"),
+				PlanOnly: true,
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"nameservers", "initial_rrsets", "force_destroy"},
+			},
+		},
+	})
+}
+
+func TestExpandInitialRRSets(t *testing.T) {
+	blocks := []interface{}{
+		map[string]interface{}{"name": "@", "type": "mx", "ttl": 300, "records": []interface{}{"10 mail.example.com."}},
+		map[string]interface{}{"name": "www", "type": "A", "ttl": 3600, "records": []interface{}{"192.0.2.1", "192.0.2.2"}},
+		map[string]interface{}{"name": "mail.example.com.", "type": "A", "ttl": 3600, "records": []interface{}{"192.0.2.25"}},
+	}
+	rrSets, err := expandInitialRRSets("example.com.", blocks)
+	if assert.NoError(t, err) && assert.Len(t, rrSets, 3) {
+		assert.Equal(t, "example.com.", rrSets[0].Name)
+		assert.Equal(t, "MX", rrSets[0].Type)
+		assert.Equal(t, 300, rrSets[0].TTL)
+		assert.Equal(t, "www.example.com.", rrSets[1].Name)
+		assert.Equal(t, []Record{
+			{Name: "www.example.com.", Type: "A", TTL: 3600, Content: "192.0.2.1"},
+			{Name: "www.example.com.", Type: "A", TTL: 3600, Content: "192.0.2.2"},
+		}, rrSets[1].Records)
+		assert.Equal(t, "mail.example.com.", rrSets[2].Name)
+	}
+
+	_, err = expandInitialRRSets("example.com.", []interface{}{
+		map[string]interface{}{"name": "www.example.org.", "type": "A", "ttl": 3600, "records": []interface{}{"192.0.2.1"}},
+	})
+	assert.ErrorContains(t, err, "outside zone example.com.")
+
+	_, err = expandInitialRRSets("example.com.", []interface{}{blocks[1], blocks[1]})
+	assert.ErrorContains(t, err, "duplicate RRset www.example.com. A")
+}
+
+func TestZoneKindWarnings(t *testing.T) {
+	metadata := []ZoneMetadata{
+		{Kind: "ALSO-NOTIFY", Metadata: []string{"192.0.2.10"}},
+		{Kind: "AXFR-MASTER-TSIG", Metadata: []string{"transfer-key"}},
+		{Kind: "ALLOW-AXFR-FROM", Metadata: []string{"AUTO-NS"}},
+	}
+
+	summaries := func(diags diag.Diagnostics) []string {
+		var result []string
+		for _, d := range diags {
+			assert.Equal(t, diag.Warning, d.Severity)
+			result = append(result, d.Summary)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{
+		"Zone metadata ALSO-NOTIFY has no effect on Native zones",
+		"Zone metadata AXFR-MASTER-TSIG has no effect on Native zones",
+	}, summaries(zoneKindWarnings("example.com.", "Native", metadata)))
+	assert.Equal(t, []string{
+		"Zone metadata AXFR-MASTER-TSIG has no effect on Master zones",
+	}, summaries(zoneKindWarnings("example.com.", "Master", metadata)))
+	assert.Empty(t, zoneKindWarnings("example.com.", "Slave", metadata))
+}
+
+func TestAccPDNSZoneKindTransitions(t *testing.T) {
+	resourceName := "powerdns_zone.test-kind"
+
+	checkMasters := func(expected ...string) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			client := testAccProvider.Meta().(*ProviderClients).PDNS
+			zone, err := client.GetZone(context.Background(), "kind.sysa.xyz.")
+			if err != nil {
+				return err
+			}
+			if len(zone.Masters) != len(expected) {
+				return fmt.Errorf("expected masters %v, got %v", expected, zone.Masters)
+			}
+			for i := range expected {
+				if zone.Masters[i] != expected[i] {
+					return fmt.Errorf("expected masters %v, got %v", expected, zone.Masters)
+				}
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testPDNSZoneConfigKind("Native", ""),
+				Check:  resource.TestCheckResourceAttr(resourceName, "kind", "Native"),
+			},
+			{
+				// Native to Master.
+				Config: testPDNSZoneConfigKind("Master", ""),
+				Check:  resource.TestCheckResourceAttr(resourceName, "kind", "Master"),
+			},
+			{
+				Config:      testPDNSZoneConfigKind("Slave", ""),
+				ExpectError: regexp.MustCompile("masters must be set when changing the kind of zone kind.sysa.xyz. to Slave"),
+			},
+			{
+				// Master to Slave, retrieving the zone right away.
+				Config: testPDNSZoneConfigKind("Slave", `masters = ["192.0.2.53"]
+	axfr_retrieve = true`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "kind", "Slave"),
+					resource.TestCheckResourceAttr(resourceName, "masters.#", "1"),
+					checkMasters("192.0.2.53"),
+				),
+			},
+			{
+				Config:      testPDNSZoneConfigKind("Master", `masters = ["192.0.2.53"]`),
+				ExpectError: regexp.MustCompile("masters attribute is supported only for Slave kind"),
+			},
+			{
+				// Slave to Master clears the primaries.
+				Config: testPDNSZoneConfigKind("Master", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "kind", "Master"),
+					checkMasters(),
+				),
+			},
+			{
+				// Master to Native.
+				Config: testPDNSZoneConfigKind("Native", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "kind", "Native"),
+					checkMasters(),
+				),
+			},
+			{
+				// Native to Slave, and back to Native.
+				Config: testPDNSZoneConfigKind("Slave", `masters = ["192.0.2.54"]`),
+				Check:  checkMasters("192.0.2.54"),
+			},
+			{
+				Config: testPDNSZoneConfigKind("Native", ""),
+				Check:  checkMasters(),
+			},
+		},
+	})
+}
+
+func TestCheckZoneDeletion(t *testing.T) {
+	const zoneJSON = `{"name": "example.com.", "rrsets": [
+		{"name": "example.com.", "type": "SOA", "ttl": 3600, "records": [{"content": "ns1.example.com. hostmaster.example.com. 1 10800 3600 604800 3600"}]},
+		{"name": "example.com.", "type": "NS", "ttl": 3600, "records": [{"content": "ns1.example.com."}]},
+		%s
+	]}`
+	withRecords := fmt.Sprintf(zoneJSON, `{"name": "www.example.com.", "type": "A", "ttl": 300, "records": [{"content": "192.0.2.1"}]},
+		{"name": "example.com.", "type": "MX", "ttl": 300, "records": [{"content": "10 mail.example.com."}]}`)
+	empty := fmt.Sprintf(zoneJSON, `{"name": "old.example.com.", "type": "A", "ttl": 300, "records": []}`)
+
+	check := func(body string, config map[string]interface{}) error {
+		client := newTestClient(func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "rrsets=true", r.URL.RawQuery)
+			return jsonResponse(http.StatusOK, body), nil
+		})
+		d := schema.TestResourceDataRaw(t, resourcePDNSZone().Schema, config)
+		return checkZoneDeletion(context.Background(), client, "example.com.", d)
+	}
+
+	err := check(withRecords, map[string]interface{}{"name": "example.com.", "kind": "Native"})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "still contains 2 RRsets")
+		assert.Contains(t, err.Error(), "example.com. MX, www.example.com. A")
+	}
+	assert.NoError(t, check(empty, map[string]interface{}{"name": "example.com.", "kind": "Native"}))
+	assert.NoError(t, check(withRecords, map[string]interface{}{"name": "example.com.", "kind": "Native", "force_destroy": true}))
+
+	err = check(empty, map[string]interface{}{"name": "example.com.", "kind": "Native", "force_destroy": true, "deletion_protection": true})
+	assert.ErrorContains(t, err, "deletion_protection enabled")
+
+	// The records of a secondary zone are transferred from its primaries,
+	// so they are not counted, and the zone is not even fetched.
+	secondary := newTestClient(func(r *http.Request) (*http.Response, error) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		return jsonResponse(http.StatusOK, withRecords), nil
+	})
+	slave := map[string]interface{}{"name": "example.com.", "kind": "Slave", "masters": []interface{}{"192.0.2.53"}}
+	d := schema.TestResourceDataRaw(t, resourcePDNSZone().Schema, slave)
+	assert.NoError(t, checkZoneDeletion(context.Background(), secondary, "example.com.", d))
+
+	slave["deletion_protection"] = true
+	d = schema.TestResourceDataRaw(t, resourcePDNSZone().Schema, slave)
+	assert.ErrorContains(t, checkZoneDeletion(context.Background(), secondary, "example.com.", d), "deletion_protection enabled")
+}
+
+func TestAccPDNSZoneDeletionProtection(t *testing.T) {
+	resourceName := "powerdns_zone.test-protected"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testPDNSZoneConfigDeletionProtection(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPDNSZoneExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "deletion_protection", "true"),
+					resource.TestCheckResourceAttr(resourceName, "force_destroy", "false"),
+				),
+			},
+			{
+				Config:      testPDNSZoneConfigDeletionProtection(true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("deletion_protection enabled"),
+			},
+			{
+				// Lifting the protection is an in-place update, after which
+				// the zone can be destroyed.
+				Config: testPDNSZoneConfigDeletionProtection(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPDNSZoneExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "deletion_protection", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPDNSZoneForceDestroy(t *testing.T) {
+	resourceName := "powerdns_zone.test-force-destroy"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPDNSZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testPDNSZoneConfigForceDestroy(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPDNSZoneExists(resourceName),
+					// A record that Terraform does not manage.
+					func(s *terraform.State) error {
+						client := testAccProvider.Meta().(*ProviderClients).PDNS
+						_, err := client.ReplaceRecordSet(context.Background(), "force-destroy.sysa.xyz.", ResourceRecordSet{
+							Name:    "unmanaged.force-destroy.sysa.xyz.",
+							Type:    "A",
+							TTL:     300,
+							Records: []Record{{Content: "192.0.2.1"}},
+						})
+						return err
+					},
+				),
+			},
+			{
+				Config:      testPDNSZoneConfigForceDestroy(false),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`still contains 1 RRsets.*unmanaged\.force-destroy\.sysa\.xyz\. A`),
+			},
+			{
+				Config: testPDNSZoneConfigForceDestroy(true),
+				Check:  resource.TestCheckResourceAttr(resourceName, "force_destroy", "true"),
+			},
+		},
+	})
+}
+
 func testAccCheckPDNSZoneDestroy(s *terraform.State) error {
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "powerdns_zone" {
@@ -622,10 +979,17 @@ resource "powerdns_zone" "test-slave" {
 }`
 
 const testPDNSZoneConfigCatalog = `
+resource "powerdns_zone" "test-catalog-producer" {
+	name = "catalog-a.example."
+	kind = "Producer"
+}
+
 resource "powerdns_zone" "test-catalog" {
 	name    = "catalog.sysa.abc."
 	kind    = "Master"
 	catalog = "catalog-a.example."
+
+	depends_on = [powerdns_zone.test-catalog-producer]
 }`
 
 const testPDNSZoneConfigSlaveWithMasters = `
@@ -673,3 +1037,79 @@ resource "powerdns_zone" "test-slave-with-ipv6-masters" {
 		"192.168.123.45",
 	]
 }`
+
+const testPDNSZoneFileContents = `$TTL 3600
+@    IN SOA ns1.sysa.xyz. hostmaster.sysa.xyz. 1 10800 3600 604800 3600
+     IN NS  ns1.sysa.xyz.
+www  IN A   192.0.2.80
+`
+
+var testPDNSZoneConfigZoneFile = fmt.Sprintf(`
+resource "powerdns_zone" "test-zone-file" {
+	name          = "zonefile.sysa.xyz."
+	kind          = "Native"
+	zone_file     = %q
+	force_destroy = true
+}`, testPDNSZoneFileContents)
+
+const testPDNSZoneConfigZoneFileInvalid = `
+resource "powerdns_zone" "test-zone-file" {
+	name      = "zonefile.sysa.xyz."
+	kind      = "Native"
+	zone_file = <<-EOT
+		@    IN SOA ns1.sysa.xyz. hostmaster.sysa.xyz. 1 10800 3600 604800 3600
+		     IN NS  ns1.sysa.xyz.
+		www  IN A   192.0.2.300
+	EOT
+}`
+
+func testPDNSZoneConfigBootstrap(ns1 string) string {
+	return fmt.Sprintf(`
+resource "powerdns_zone" "test-bootstrap" {
+	name          = "bootstrap.sysa.xyz."
+	kind          = "Native"
+	nameservers   = ["ns1.bootstrap.sysa.xyz.", "ns2.sysa.xyz."]
+	force_destroy = true
+
+	initial_rrsets {
+		name    = "ns1"
+		type    = "A"
+		records = [%q]
+	}
+
+	initial_rrsets {
+		name    = "@"
+		type    = "TXT"
+		ttl     = 300
+		records = ["\"bootstrapped\""]
+	}
+}`, ns1)
+}
+
+func testPDNSZoneConfigKind(kind string, extra string) string {
+	return fmt.Sprintf(`
+resource "powerdns_zone" "test-kind" {
+	name          = "kind.sysa.xyz."
+	kind          = %q
+	force_destroy = true
+	%s
+}`, kind, extra)
+}
+
+func testPDNSZoneConfigDeletionProtection(protected bool) string {
+	return fmt.Sprintf(`
+resource "powerdns_zone" "test-protected" {
+	name                = "protected.sysa.xyz."
+	kind                = "Native"
+	deletion_protection = %t
+}`, protected)
+}
+
+func testPDNSZoneConfigForceDestroy(force bool) string {
+	return fmt.Sprintf(`
+resource "powerdns_zone" "test-force-destroy" {
+	name          = "force-destroy.sysa.xyz."
+	kind          = "Native"
+	force_destroy = %t
+}`, force)
+}
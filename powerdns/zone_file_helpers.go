@@ -0,0 +1,445 @@
+package powerdns
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/terraform-providers/terraform-provider-powerdns/pathorcontents"
+)
+
+// zoneFileRecord is a resource record read from a BIND zone file.
+type zoneFileRecord struct {
+	line   int
+	name   string
+	ttl    int
+	class  string
+	rrType string
+	rdata  []string
+}
+
+// zoneFileEntry is one logical entry of a zone file: a physical line, or
+// several when parentheses continue it.
+type zoneFileEntry struct {
+	line int
+	// continued is set when the entry starts with whitespace, which makes
+	// it reuse the owner name of the previous record.
+	continued bool
+	tokens    []string
+}
+
+// zoneFileRecordTypes are the record types accepted in a zone file besides
+// the generic TYPEnnn form of RFC 3597.
+var zoneFileRecordTypes = map[string]bool{
+	"A": true, "AAAA": true, "AFSDB": true, "ALIAS": true, "APL": true, "CAA": true,
+	"CDNSKEY": true, "CDS": true, "CERT": true, "CNAME": true, "CSYNC": true,
+	"DHCID": true, "DLV": true, "DNAME": true, "DNSKEY": true, "DS": true,
+	"EUI48": true, "EUI64": true, "HINFO": true, "HTTPS": true, "IPSECKEY": true,
+	"KEY": true, "KX": true, "L32": true, "L64": true, "LOC": true, "LP": true,
+	"LUA": true, "MAILA": true, "MAILB": true, "MINFO": true, "MR": true,
+	"MX": true, "NAPTR": true, "NID": true, "NS": true, "NSEC": true,
+	"NSEC3": true, "NSEC3PARAM": true, "OPENPGPKEY": true, "PTR": true,
+	"RKEY": true, "RP": true, "RRSIG": true, "SIG": true, "SMIMEA": true,
+	"SOA": true, "SPF": true, "SRV": true, "SSHFP": true, "SVCB": true,
+	"TKEY": true, "TLSA": true, "TSIG": true, "TXT": true, "URI": true,
+	"ZONEMD": true,
+}
+
+// readZoneFile returns the contents of a zone_file value, which is either a
+// path or the zone file itself.
+func readZoneFile(value string) (string, error) {
+	content, _, err := pathorcontents.Read(value)
+	if err != nil {
+		return "", fmt.Errorf("error reading zone_file: %w", err)
+	}
+	return content, nil
+}
+
+// zoneFileHash returns the SHA-256 of the zone file a zone_file value
+// refers to. It is stored in state instead of the file, which may be large.
+func zoneFileHash(value string) string {
+	if value == "" {
+		return ""
+	}
+	content, err := readZoneFile(value)
+	if err != nil {
+		content = value
+	}
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// validateZoneFile checks the syntax of a zone_file value, so that errors
+// are reported at plan time with their line number.
+func validateZoneFile(v interface{}, k string) (ws []string, errors []error) {
+	content, err := readZoneFile(v.(string))
+	if err != nil {
+		errors = append(errors, err)
+		return
+	}
+	if _, err := parseZoneFile(content, ""); err != nil {
+		errors = append(errors, fmt.Errorf("%q: %s", k, err))
+	}
+	return
+}
+
+// parseZoneFile parses a BIND zone file in the format of RFC 1035. Relative
+// names are made absolute with origin; with an empty origin they are kept
+// as they are. $INCLUDE is rejected, as PowerDNS cannot follow it, and
+// $GENERATE is passed on to PowerDNS without expanding it.
+func parseZoneFile(content string, origin string) ([]zoneFileRecord, error) {
+	entries, err := splitZoneFile(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []zoneFileRecord
+	var owner string
+	// hasOwner is tracked separately from owner, which "@" leaves empty
+	// when there is no origin.
+	hasOwner := false
+	defaultTTL, lastTTL := 0, 0
+	for _, entry := range entries {
+		tokens := entry.tokens
+
+		if strings.HasPrefix(tokens[0], "$") && !entry.continued {
+			switch strings.ToUpper(tokens[0]) {
+			case "$ORIGIN":
+				if len(tokens) != 2 {
+					return nil, fmt.Errorf("line %d: $ORIGIN takes one name", entry.line)
+				}
+				origin = zoneFileName(tokens[1], origin)
+			case "$TTL":
+				if len(tokens) != 2 {
+					return nil, fmt.Errorf("line %d: $TTL takes one value", entry.line)
+				}
+				ttl, err := parseZoneFileTTL(tokens[1])
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %s", entry.line, err)
+				}
+				defaultTTL = ttl
+			case "$INCLUDE":
+				return nil, fmt.Errorf("line %d: $INCLUDE is not supported, include the contents of the file instead", entry.line)
+			case "$GENERATE":
+				if len(tokens) < 4 {
+					return nil, fmt.Errorf("line %d: $GENERATE takes a range, an owner, a type and data", entry.line)
+				}
+			default:
+				return nil, fmt.Errorf("line %d: unknown directive %s", entry.line, tokens[0])
+			}
+			continue
+		}
+
+		if !entry.continued {
+			owner = zoneFileName(tokens[0], origin)
+			hasOwner = true
+			tokens = tokens[1:]
+		} else if !hasOwner {
+			return nil, fmt.Errorf("line %d: record without an owner name", entry.line)
+		}
+
+		record := zoneFileRecord{line: entry.line, name: owner, ttl: -1, class: "IN"}
+		for i := 0; i < 2 && len(tokens) > 0; i++ {
+			if isZoneFileClass(tokens[0]) {
+				record.class = strings.ToUpper(tokens[0])
+			} else if ttl, err := parseZoneFileTTL(tokens[0]); err == nil && record.ttl < 0 {
+				record.ttl = ttl
+			} else {
+				break
+			}
+			tokens = tokens[1:]
+		}
+
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("line %d: missing record type", entry.line)
+		}
+		record.rrType = strings.ToUpper(tokens[0])
+		if !zoneFileRecordTypes[record.rrType] && !isGenericRecordType(record.rrType) {
+			return nil, fmt.Errorf("line %d: unknown record type %q", entry.line, tokens[0])
+		}
+		record.rdata = tokens[1:]
+		if err := validateZoneFileRData(record); err != nil {
+			return nil, fmt.Errorf("line %d: %s", entry.line, err)
+		}
+
+		switch {
+		case record.ttl >= 0:
+		case defaultTTL > 0:
+			record.ttl = defaultTTL
+		default:
+			record.ttl = lastTTL
+		}
+		lastTTL = record.ttl
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// splitZoneFile splits a zone file into entries, removing comments and
+// joining lines continued with parentheses.
+func splitZoneFile(content string) ([]zoneFileEntry, error) {
+	var entries []zoneFileEntry
+	var current zoneFileEntry
+	var token strings.Builder
+	inToken, inQuotes, inComment := false, false, false
+	parens, parenLine := 0, 0
+	line, lineStart := 1, true
+
+	endToken := func() {
+		if inToken {
+			current.tokens = append(current.tokens, token.String())
+			token.Reset()
+			inToken = false
+		}
+	}
+
+	runes := []rune(content)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		if r == '\n' {
+			if inQuotes {
+				return nil, fmt.Errorf("line %d: unterminated quoted string", line)
+			}
+			inComment = false
+			endToken()
+			if parens == 0 {
+				if len(current.tokens) > 0 {
+					entries = append(entries, current)
+				}
+				current = zoneFileEntry{}
+			}
+			line++
+			lineStart = true
+			continue
+		}
+		if inComment {
+			continue
+		}
+
+		if lineStart && parens == 0 {
+			current = zoneFileEntry{line: line, continued: r == ' ' || r == '\t'}
+		}
+		lineStart = false
+
+		switch {
+		case inQuotes:
+			token.WriteRune(r)
+			if r == '\\' && i+1 < len(runes) {
+				i++
+				token.WriteRune(runes[i])
+			} else if r == '"' {
+				inQuotes = false
+			}
+		case r == '\\':
+			inToken = true
+			token.WriteRune(r)
+			if i+1 < len(runes) {
+				i++
+				token.WriteRune(runes[i])
+			}
+		case r == '"':
+			inToken, inQuotes = true, true
+			token.WriteRune(r)
+		case r == ';':
+			endToken()
+			inComment = true
+		case r == '(':
+			endToken()
+			if parens == 0 {
+				parenLine = line
+			}
+			parens++
+		case r == ')':
+			endToken()
+			if parens == 0 {
+				return nil, fmt.Errorf("line %d: unexpected )", line)
+			}
+			parens--
+		case r == ' ' || r == '\t' || r == '\r':
+			endToken()
+		default:
+			inToken = true
+			token.WriteRune(r)
+		}
+	}
+
+	if inQuotes {
+		return nil, fmt.Errorf("line %d: unterminated quoted string", line)
+	}
+	if parens > 0 {
+		return nil, fmt.Errorf("line %d: unclosed (", parenLine)
+	}
+	endToken()
+	if len(current.tokens) > 0 {
+		entries = append(entries, current)
+	}
+	return entries, nil
+}
+
+// zoneFileName makes name absolute relative to origin; "@" is the origin
+// itself.
+func zoneFileName(name string, origin string) string {
+	switch {
+	case name == "@":
+		return origin
+	case strings.HasSuffix(name, ".") || origin == "":
+		return name
+	case origin == ".":
+		return name + "."
+	default:
+		return name + "." + origin
+	}
+}
+
+func isZoneFileClass(token string) bool {
+	switch strings.ToUpper(token) {
+	case "IN", "CH", "HS", "CS":
+		return true
+	}
+	return false
+}
+
+func isGenericRecordType(rrType string) bool {
+	number, found := strings.CutPrefix(rrType, "TYPE")
+	if !found {
+		return false
+	}
+	_, err := strconv.ParseUint(number, 10, 16)
+	return err == nil
+}
+
+// parseZoneFileTTL parses a TTL given in seconds or with the BIND units
+// s, m, h, d and w, such as "1h30m".
+func parseZoneFileTTL(value string) (int, error) {
+	if n, err := strconv.ParseUint(value, 10, 31); err == nil {
+		return int(n), nil
+	}
+
+	total, number := 0, ""
+	for _, r := range strings.ToLower(value) {
+		if r >= '0' && r <= '9' {
+			number += string(r)
+			continue
+		}
+		unit := map[rune]int{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}[r]
+		n, err := strconv.Atoi(number)
+		if unit == 0 || err != nil {
+			return 0, fmt.Errorf("invalid TTL %q", value)
+		}
+		total += n * unit
+		number = ""
+	}
+	if number != "" || total == 0 && value != "0" {
+		return 0, fmt.Errorf("invalid TTL %q", value)
+	}
+	return total, nil
+}
+
+// validateZoneFileRData checks the data of the most common record types;
+// the data of other types is left to PowerDNS.
+func validateZoneFileRData(record zoneFileRecord) error {
+	rdata := record.rdata
+	if len(rdata) == 0 {
+		return fmt.Errorf("missing data for %s record", record.rrType)
+	}
+
+	fields := map[string]int{"A": 1, "AAAA": 1, "CNAME": 1, "DNAME": 1, "NS": 1, "PTR": 1, "MX": 2, "SRV": 4, "SOA": 7}
+	if n, ok := fields[record.rrType]; ok && len(rdata) != n {
+		return fmt.Errorf("%s record takes %d fields, got %d", record.rrType, n, len(rdata))
+	}
+
+	switch record.rrType {
+	case "A":
+		if ip := net.ParseIP(rdata[0]); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("invalid IPv4 address %q", rdata[0])
+		}
+	case "AAAA":
+		if ip := net.ParseIP(rdata[0]); ip == nil || ip.To4() != nil {
+			return fmt.Errorf("invalid IPv6 address %q", rdata[0])
+		}
+	case "MX":
+		if _, err := strconv.ParseUint(rdata[0], 10, 16); err != nil {
+			return fmt.Errorf("invalid MX preference %q", rdata[0])
+		}
+	case "SRV":
+		for _, field := range rdata[:3] {
+			if _, err := strconv.ParseUint(field, 10, 16); err != nil {
+				return fmt.Errorf("invalid SRV priority, weight or port %q", field)
+			}
+		}
+	case "SOA":
+		if _, err := strconv.ParseUint(rdata[2], 10, 32); err != nil {
+			return fmt.Errorf("invalid SOA serial %q", rdata[2])
+		}
+		for _, field := range rdata[3:] {
+			if _, err := parseZoneFileTTL(field); err != nil {
+				return fmt.Errorf("invalid SOA timer: %s", err)
+			}
+		}
+	}
+	return nil
+}
+
+// rrSetsFromZoneFile parses a zone file and groups its records into RRsets,
+// sorted by name and type. A record's data is rejoined with single spaces,
+// which is how PowerDNS represents record content.
+func rrSetsFromZoneFile(content string, origin string) ([]ResourceRecordSet, error) {
+	records, err := parseZoneFile(content, origin)
+	if err != nil {
+		return nil, err
+	}
+
+	var rrSets []ResourceRecordSet
+	index := map[string]int{}
+	for _, record := range records {
+		rrSet := ResourceRecordSet{Name: record.name, Type: record.rrType, TTL: record.ttl}
+		i, ok := index[rrSet.ID()]
+		if !ok {
+			i = len(rrSets)
+			index[rrSet.ID()] = i
+			rrSets = append(rrSets, rrSet)
+		}
+		rrSets[i].Records = append(rrSets[i].Records, Record{
+			Name:    record.name,
+			Type:    record.rrType,
+			Content: strings.Join(record.rdata, " "),
+			TTL:     record.ttl,
+		})
+	}
+	sortRRSets(rrSets)
+	return rrSets, nil
+}
+
+// zoneFileFromRRSets renders RRsets as zone file text, one record per line
+// in the layout of the PowerDNS export endpoint. Disabled records are left
+// out, as the export endpoint does.
+func zoneFileFromRRSets(rrSets []ResourceRecordSet) string {
+	var b strings.Builder
+	for _, rrSet := range rrSets {
+		for _, record := range rrSet.Records {
+			if record.Disabled {
+				continue
+			}
+			fmt.Fprintf(&b, "%s\t%d\tIN\t%s\t%s\n", rrSet.Name, rrSet.TTL, rrSet.Type, record.Content)
+		}
+	}
+	return b.String()
+}
+
+// sortRRSets sorts RRsets by name and then by type, ignoring case.
+func sortRRSets(rrSets []ResourceRecordSet) {
+	sort.SliceStable(rrSets, func(i, j int) bool {
+		a, b := strings.ToLower(rrSets[i].Name), strings.ToLower(rrSets[j].Name)
+		if a != b {
+			return a < b
+		}
+		return rrSets[i].Type < rrSets[j].Type
+	})
+}
@@ -0,0 +1,155 @@
+package powerdns
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testZoneFile = `$ORIGIN example.com.
+$TTL 1h
+@   IN  SOA ns1 hostmaster (
+        2024010101 ; serial
+        3600       ; refresh
+        900        ; retry
+        1w         ; expire
+        300 )      ; minimum
+    IN  NS  ns1
+    IN  NS  ns2.example.net.
+ns1     300 IN A    192.0.2.1
+www         CNAME   @
+txt     IN 60 TXT   "v=spf1 -all ; not a comment" "second string"
+mail        MX  10 ns1
+        AAAA 2001:db8::25
+`
+
+func TestParseZoneFile(t *testing.T) {
+	records, err := parseZoneFile(testZoneFile, "example.com.")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var got []string
+	for _, record := range records {
+		got = append(got, record.name+" "+record.rrType)
+	}
+	assert.Equal(t, []string{
+		"example.com. SOA",
+		"example.com. NS",
+		"example.com. NS",
+		"ns1.example.com. A",
+		"www.example.com. CNAME",
+		"txt.example.com. TXT",
+		"mail.example.com. MX",
+		"mail.example.com. AAAA",
+	}, got)
+
+	assert.Equal(t, 3, records[0].line)
+	assert.Equal(t, 3600, records[0].ttl)
+	assert.Len(t, records[0].rdata, 7)
+	assert.Equal(t, 300, records[3].ttl)
+	assert.Equal(t, 60, records[5].ttl)
+	assert.Equal(t, []string{`"v=spf1 -all ; not a comment"`, `"second string"`}, records[5].rdata)
+	assert.Equal(t, 15, records[7].line)
+}
+
+func TestParseZoneFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{"unknown type", "@ 3600 IN SOA ns1 host 1 2 3 4 5\nwww IN BOGUS foo\n", `line 2: unknown record type "BOGUS"`},
+		{"bad address", "www A 192.0.2.300\n", `line 1: invalid IPv4 address "192.0.2.300"`},
+		{"short SOA", "@ SOA ns1 host 1 2 3\n", "line 1: SOA record takes 7 fields, got 5"},
+		{"unclosed parenthesis", "\n@ SOA ns1 host (\n 1 2 3 4 5\n", "line 2: unclosed ("},
+		{"unterminated quote", "txt TXT \"abc\nwww A 192.0.2.1\n", "line 1: unterminated quoted string"},
+		{"include", "$INCLUDE other.zone\n", "line 1: $INCLUDE is not supported"},
+		{"no owner", "  A 192.0.2.1\n", "line 1: record without an owner name"},
+		{"missing data", "www IN A\n", "line 1: missing data for A record"},
+		{"bad TTL", "$TTL 1x\n", `line 1: invalid TTL "1x"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseZoneFile(tt.content, "example.com.")
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.err)
+			}
+		})
+	}
+}
+
+func TestParseZoneFileTTL(t *testing.T) {
+	for value, expected := range map[string]int{"0": 0, "300": 300, "1h30m": 5400, "1W": 604800, "2d": 172800} {
+		ttl, err := parseZoneFileTTL(value)
+		assert.NoError(t, err, value)
+		assert.Equal(t, expected, ttl, value)
+	}
+	for _, value := range []string{"", "h", "10x", "1h5"} {
+		_, err := parseZoneFileTTL(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestZoneFileHashAndValidation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "example.com.zone")
+	if err := os.WriteFile(path, []byte(testZoneFile), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// A path and the contents it holds hash the same.
+	assert.Equal(t, zoneFileHash(testZoneFile), zoneFileHash(path))
+	assert.Len(t, zoneFileHash(path), 64)
+	assert.Equal(t, "", zoneFileHash(""))
+
+	_, errs := validateZoneFile(path, "zone_file")
+	assert.Empty(t, errs)
+	_, errs = validateZoneFile("www IN A not-an-address\n", "zone_file")
+	assert.Len(t, errs, 1)
+
+	// Without an origin "@" has no name, but still sets the owner for the
+	// lines that follow it.
+	_, errs = validateZoneFile(`$TTL 3600
+@ IN SOA ns1.example.com. hostmaster.example.com. (
+	2024010101 ; serial
+	3600       ; refresh
+	600        ; retry
+	604800     ; expire
+	300 )      ; minimum
+  IN NS ns1.example.com.
+`, "zone_file")
+	assert.Empty(t, errs)
+}
+
+func TestRRSetsFromZoneFile(t *testing.T) {
+	export := "www.example.com.\t300\tIN\tA\t192.0.2.2\n" +
+		"example.com.\t3600\tIN\tNS\tns1.example.com.\n" +
+		"www.example.com.\t300\tIN\tA\t192.0.2.1\n" +
+		"example.com.\t3600\tIN\tTXT\t\"v=spf1\" \"-all\"\n"
+
+	rrSets, err := rrSetsFromZoneFile(export, "example.com.")
+	if !assert.NoError(t, err) {
+		return
+	}
+	if assert.Len(t, rrSets, 3) {
+		assert.Equal(t, "example.com.", rrSets[0].Name)
+		assert.Equal(t, "NS", rrSets[0].Type)
+		assert.Equal(t, `"v=spf1" "-all"`, rrSets[1].Records[0].Content)
+		assert.Equal(t, "www.example.com.", rrSets[2].Name)
+		assert.Equal(t, 300, rrSets[2].TTL)
+		assert.Len(t, rrSets[2].Records, 2)
+	}
+
+	// Rendering the RRsets again gives an equivalent zone file.
+	rendered := zoneFileFromRRSets(rrSets)
+	again, err := rrSetsFromZoneFile(rendered, "example.com.")
+	assert.NoError(t, err)
+	assert.Equal(t, rrSets, again)
+
+	disabled := []ResourceRecordSet{{Name: "a.example.com.", Type: "A", TTL: 60, Records: []Record{{Content: "192.0.2.9", Disabled: true}}}}
+	assert.Equal(t, "", zoneFileFromRRSets(disabled))
+	assert.Empty(t, enabledRRSets(disabled))
+}